@@ -5,6 +5,7 @@ import (
 
 	"github.com/disgoorg/log"
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
 
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
@@ -25,8 +26,12 @@ type Client interface {
 	Logger() log.Logger
 
 	// Close will clean up all disgo internals and close the discord gracefully.
+	// This invalidates the gateway sessions and deletes them from the gateway.SessionStore.
 	Close(ctx context.Context)
 
+	// CloseResumable works like Close, but keeps the gateway sessions, so they can be resumed from the gateway.SessionStore after a restart.
+	CloseResumable(ctx context.Context)
+
 	// Token returns the configured bot token.
 	Token() string
 
@@ -139,21 +144,40 @@ func (c *clientImpl) Logger() log.Logger {
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
+	c.close(ctx, false)
+}
+
+func (c *clientImpl) CloseResumable(ctx context.Context) {
+	c.close(ctx, true)
+}
+
+func (c *clientImpl) close(ctx context.Context, resumable bool) {
 	if c.voiceManager != nil {
 		c.voiceManager.Close(ctx)
 	}
 	if c.gateway != nil {
-		c.gateway.Close(ctx)
+		if resumable {
+			c.gateway.CloseWithCode(ctx, websocket.CloseServiceRestart, "Restarting")
+		} else {
+			c.gateway.Close(ctx)
+		}
 	}
 	if c.restServices != nil {
 		c.restServices.Close(ctx)
 	}
 	if c.shardManager != nil {
-		c.shardManager.Close(ctx)
+		if resumable {
+			c.shardManager.CloseResumable(ctx)
+		} else {
+			c.shardManager.Close(ctx)
+		}
 	}
 	if c.httpServer != nil {
 		c.httpServer.Close(ctx)
 	}
+	if c.eventManager != nil {
+		c.eventManager.Close(ctx)
+	}
 }
 
 func (c *clientImpl) Token() string {
@@ -1,7 +1,9 @@
 package bot
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/disgoorg/log"
 
@@ -271,7 +273,10 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 			},
 		}, config.GatewayConfigOpts...)
 
-		config.Gateway = gateway.New(token, gatewayEventHandlerFunc(client), nil, config.GatewayConfigOpts...)
+		eventHandlerFunc := gatewayEventHandlerFunc(client)
+		config.GatewayConfigOpts = append(config.GatewayConfigOpts, withStatusChangeEvents(eventHandlerFunc))
+
+		config.Gateway = gateway.New(token, eventHandlerFunc, nil, config.GatewayConfigOpts...)
 	}
 	client.gateway = config.Gateway
 
@@ -301,12 +306,22 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 				},
 			),
 			sharding.WithLogger(client.logger),
+			sharding.WithRecommendedShardCountFunc(func(ctx context.Context) (int, error) {
+				gatewayBot, err := client.restServices.GetGatewayBot(rest.WithCtx(ctx))
+				if err != nil {
+					return 0, err
+				}
+				return gatewayBot.Shards, nil
+			}),
 			func(config *sharding.Config) {
 				config.RateRateLimiterConfigOpts = append([]sharding.RateLimiterConfigOpt{sharding.WithRateLimiterLogger(client.logger), sharding.WithMaxConcurrency(gatewayBotRs.SessionStartLimit.MaxConcurrency)}, config.RateRateLimiterConfigOpts...)
 			},
 		}, config.ShardManagerConfigOpts...)
 
-		config.ShardManager = sharding.New(token, gatewayEventHandlerFunc(client), config.ShardManagerConfigOpts...)
+		eventHandlerFunc := gatewayEventHandlerFunc(client)
+		config.ShardManagerConfigOpts = append(config.ShardManagerConfigOpts, sharding.WithGatewayConfigOpts(withStatusChangeEvents(eventHandlerFunc)))
+
+		config.ShardManager = sharding.New(token, eventHandlerFunc, config.ShardManagerConfigOpts...)
 	}
 	client.shardManager = config.ShardManager
 
@@ -331,3 +346,44 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 
 	return client, nil
 }
+
+// withStatusChangeEvents passes every gateway.StatusChange to the EventManager as gateway.EventTypeStatusChange.
+// The events are dispatched in order from a separate goroutine, as the gateway.StatusChangeFunc must not block.
+// A gateway.StatusChangeFunc configured by the user is still called.
+func withStatusChangeEvents(eventHandlerFunc gateway.EventHandlerFunc) gateway.ConfigOpt {
+	return func(config *gateway.Config) {
+		var (
+			mu      sync.Mutex
+			queue   []gateway.StatusChange
+			running bool
+		)
+		dispatch := func(shardID int) {
+			for {
+				mu.Lock()
+				if len(queue) == 0 {
+					running = false
+					mu.Unlock()
+					return
+				}
+				change := queue[0]
+				queue = queue[1:]
+				mu.Unlock()
+				eventHandlerFunc(gateway.EventTypeStatusChange, 0, shardID, change)
+			}
+		}
+
+		statusChangeFunc := config.StatusChangeFunc
+		config.StatusChangeFunc = func(g gateway.Gateway, change gateway.StatusChange) {
+			if statusChangeFunc != nil {
+				statusChangeFunc(g, change)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			queue = append(queue, change)
+			if !running {
+				running = true
+				go dispatch(g.ShardID())
+			}
+		}
+	}
+}
@@ -3,44 +3,293 @@ package bot
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // WaitForEvent waits for an event passing the filterFunc and then calls the actionFunc. You can cancel this function with the passed context.Context and the cancelFunc gets called then.
 func WaitForEvent[E Event](client Client, ctx context.Context, filterFunc func(e E) bool, actionFunc func(e E), cancelFunc func()) {
-	ch, cancel := NewEventCollector(client, filterFunc)
+	result := NewEventCollectorBuilder[E](client).
+		AddFilter(filterFunc).
+		SetMaxEvents(1).
+		Collect(ctx)
 
-	select {
-	case <-ctx.Done():
-		cancel()
+	if result.Reason != EventCollectorEndReasonMaxEvents {
 		if cancelFunc != nil {
 			cancelFunc()
 		}
-	case e := <-ch:
-		cancel()
-		if actionFunc != nil {
-			actionFunc(e)
-		}
+		return
+	}
+	if actionFunc != nil {
+		actionFunc(result.Events[0])
 	}
 }
 
 // NewEventCollector returns a channel in which the events of type T gets sent which pass the passed filter and a function which can be used to stop the event collector.
 // The close function needs to be called to stop the event collector.
+// Use NewEventCollectorBuilder for limits, timeouts or buffering.
 func NewEventCollector[E Event](client Client, filterFunc func(e E) bool) (<-chan E, func()) {
-	ch := make(chan E)
-	var once sync.Once
+	collector := NewEventCollectorBuilder[E](client).
+		AddFilter(filterFunc).
+		Build()
+	return collector.Events(), collector.Stop
+}
 
-	handler := NewListenerFunc(func(e E) {
-		if !filterFunc(e) {
-			return
+// EventCollectorEndReason is the reason why an EventCollector stopped collecting events.
+type EventCollectorEndReason int
+
+// All EventCollectorEndReason(s).
+const (
+	// EventCollectorEndReasonStopped means the EventCollector was stopped or the context.Context passed to Collect was done.
+	EventCollectorEndReasonStopped EventCollectorEndReason = iota
+	// EventCollectorEndReasonMaxEvents means the EventCollector collected the maximum number of events.
+	EventCollectorEndReasonMaxEvents
+	// EventCollectorEndReasonIdleTimeout means the EventCollector didn't collect an event within the idle timeout.
+	EventCollectorEndReasonIdleTimeout
+	// EventCollectorEndReasonTimeout means the overall timeout of the EventCollector passed.
+	EventCollectorEndReasonTimeout
+)
+
+func (r EventCollectorEndReason) String() string {
+	switch r {
+	case EventCollectorEndReasonStopped:
+		return "stopped"
+	case EventCollectorEndReasonMaxEvents:
+		return "max events"
+	case EventCollectorEndReasonIdleTimeout:
+		return "idle timeout"
+	case EventCollectorEndReasonTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// EventCollectorResult is returned by EventCollector.Collect and contains all collected events.
+type EventCollectorResult[E Event] struct {
+	Events []E
+	Reason EventCollectorEndReason
+	// Dropped is the number of events which were dropped because the EventCollector was non-blocking and its buffer was full.
+	Dropped int
+}
+
+// NewEventCollectorBuilder returns a new EventCollectorBuilder for events of type E.
+func NewEventCollectorBuilder[E Event](client Client) *EventCollectorBuilder[E] {
+	return &EventCollectorBuilder[E]{client: client}
+}
+
+// EventCollectorBuilder is a builder for an EventCollector.
+type EventCollectorBuilder[E Event] struct {
+	client      Client
+	filters     []func(e E) bool
+	maxEvents   int
+	idleTimeout time.Duration
+	timeout     time.Duration
+	bufferSize  int
+	nonBlocking bool
+}
+
+// AddFilter adds a filter func to the EventCollectorBuilder. Events need to pass all filters to be collected.
+func (b *EventCollectorBuilder[E]) AddFilter(filterFunc func(e E) bool) *EventCollectorBuilder[E] {
+	b.filters = append(b.filters, filterFunc)
+	return b
+}
+
+// SetMaxEvents sets the number of events after which the EventCollector stops. 0 means no limit.
+func (b *EventCollectorBuilder[E]) SetMaxEvents(maxEvents int) *EventCollectorBuilder[E] {
+	b.maxEvents = maxEvents
+	return b
+}
+
+// SetIdleTimeout sets the duration after which the EventCollector stops if no event was collected. 0 means no idle timeout.
+func (b *EventCollectorBuilder[E]) SetIdleTimeout(idleTimeout time.Duration) *EventCollectorBuilder[E] {
+	b.idleTimeout = idleTimeout
+	return b
+}
+
+// SetTimeout sets the duration after which the EventCollector stops. 0 means no timeout.
+func (b *EventCollectorBuilder[E]) SetTimeout(timeout time.Duration) *EventCollectorBuilder[E] {
+	b.timeout = timeout
+	return b
+}
+
+// SetBufferSize sets the number of events which are buffered until they are read. 0 means unbuffered.
+func (b *EventCollectorBuilder[E]) SetBufferSize(bufferSize int) *EventCollectorBuilder[E] {
+	b.bufferSize = bufferSize
+	return b
+}
+
+// SetNonBlocking sets whether events should be dropped instead of blocking the dispatching of events if the buffer is full.
+func (b *EventCollectorBuilder[E]) SetNonBlocking(nonBlocking bool) *EventCollectorBuilder[E] {
+	b.nonBlocking = nonBlocking
+	return b
+}
+
+// Build creates the EventCollector and starts collecting events.
+func (b *EventCollectorBuilder[E]) Build() *EventCollector[E] {
+	c := &EventCollector[E]{
+		filters:     append([]func(e E) bool{}, b.filters...),
+		maxEvents:   b.maxEvents,
+		nonBlocking: b.nonBlocking,
+		ch:          make(chan E, b.bufferSize),
+		collected:   make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	c.handle = b.client.EventManager().AddListener(NewListenerFunc(c.onEvent))
+	if b.idleTimeout > 0 || b.timeout > 0 {
+		go c.watchTimeouts(b.idleTimeout, b.timeout)
+	}
+	return c
+}
+
+// Collect builds the EventCollector and collects events until it stops or the context.Context is done.
+func (b *EventCollectorBuilder[E]) Collect(ctx context.Context) EventCollectorResult[E] {
+	return b.Build().Collect(ctx)
+}
+
+// EventCollector collects events of type E until one of its limits is reached or it is stopped.
+type EventCollector[E Event] struct {
+	filters     []func(e E) bool
+	maxEvents   int
+	nonBlocking bool
+	handle      *ListenerHandle
+
+	// mu guards sending to & closing ch
+	mu        sync.Mutex
+	ch        chan E
+	ended     bool
+	count     int
+	dropped   int
+	collected chan struct{}
+
+	doneOnce sync.Once
+	done     chan struct{}
+	reason   EventCollectorEndReason
+}
+
+// Events returns the channel the collected events are sent to. It is closed once the EventCollector stopped.
+func (c *EventCollector[E]) Events() <-chan E {
+	return c.ch
+}
+
+// Collect reads all events until the EventCollector stopped and returns them. If the context.Context is done, the EventCollector is stopped.
+func (c *EventCollector[E]) Collect(ctx context.Context) EventCollectorResult[E] {
+	var events []E
+	ctxDone := ctx.Done()
+	for {
+		select {
+		case e, ok := <-c.ch:
+			if !ok {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				return EventCollectorResult[E]{
+					Events:  events,
+					Reason:  c.reason,
+					Dropped: c.dropped,
+				}
+			}
+			events = append(events, e)
+
+		case <-ctxDone:
+			ctxDone = nil
+			c.Stop()
 		}
-		ch <- e
+	}
+}
+
+// Stop stops the EventCollector. Calling Stop multiple times is a no-op.
+func (c *EventCollector[E]) Stop() {
+	c.end(EventCollectorEndReasonStopped)
+}
+
+func (c *EventCollector[E]) end(reason EventCollectorEndReason) {
+	c.doneOnce.Do(func() {
+		c.reason = reason
+		// unblocks onEvent, so we can acquire the lock
+		close(c.done)
+		c.handle.Remove()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.ended = true
+		close(c.ch)
 	})
-	client.EventManager().AddEventListeners(handler)
+}
+
+func (c *EventCollector[E]) onEvent(e E) {
+	for _, filter := range c.filters {
+		if !filter(e) {
+			return
+		}
+	}
 
-	return ch, func() {
-		once.Do(func() {
-			client.EventManager().RemoveEventListeners(handler)
-			close(ch)
-		})
+	c.mu.Lock()
+	if c.ended {
+		c.mu.Unlock()
+		return
+	}
+	if c.nonBlocking {
+		select {
+		case c.ch <- e:
+		default:
+			c.dropped++
+			c.mu.Unlock()
+			return
+		}
+	} else {
+		select {
+		case c.ch <- e:
+		case <-c.done:
+			c.mu.Unlock()
+			return
+		}
+	}
+	c.count++
+	maxReached := c.maxEvents > 0 && c.count >= c.maxEvents
+	c.mu.Unlock()
+
+	select {
+	case c.collected <- struct{}{}:
+	default:
+	}
+	if maxReached {
+		c.end(EventCollectorEndReasonMaxEvents)
+	}
+}
+
+func (c *EventCollector[E]) watchTimeouts(idleTimeout time.Duration, timeout time.Duration) {
+	var timeoutCh, idleCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+	var idleTimer *time.Timer
+	if idleTimeout > 0 {
+		idleTimer = time.NewTimer(idleTimeout)
+		defer idleTimer.Stop()
+		idleCh = idleTimer.C
+	}
+
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case <-c.collected:
+			if idleTimer != nil {
+				if !idleTimer.Stop() {
+					<-idleTimer.C
+				}
+				idleTimer.Reset(idleTimeout)
+			}
+
+		case <-idleCh:
+			c.end(EventCollectorEndReasonIdleTimeout)
+			return
+
+		case <-timeoutCh:
+			c.end(EventCollectorEndReasonTimeout)
+			return
+		}
 	}
 }
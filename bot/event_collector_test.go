@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	Client
+	eventManager EventManager
+}
+
+func (c *testClient) EventManager() EventManager {
+	return c.eventManager
+}
+
+func newTestClient() *testClient {
+	return &testClient{eventManager: NewEventManager(nil)}
+}
+
+func TestEventCollectorMaxEvents(t *testing.T) {
+	client := newTestClient()
+	collector := NewEventCollectorBuilder[*testEvent](client).
+		AddFilter(func(e *testEvent) bool {
+			return e.n%2 == 0
+		}).
+		SetMaxEvents(2).
+		SetBufferSize(10).
+		Build()
+
+	for i := 0; i < 10; i++ {
+		client.EventManager().DispatchEvent(&testEvent{n: i})
+	}
+
+	result := collector.Collect(context.Background())
+	assert.Equal(t, EventCollectorEndReasonMaxEvents, result.Reason)
+	if assert.Len(t, result.Events, 2) {
+		assert.Equal(t, 0, result.Events[0].n)
+		assert.Equal(t, 2, result.Events[1].n)
+	}
+}
+
+func TestEventCollectorNonBlocking(t *testing.T) {
+	client := newTestClient()
+	collector := NewEventCollectorBuilder[*testEvent](client).
+		SetBufferSize(1).
+		SetNonBlocking(true).
+		SetIdleTimeout(50 * time.Millisecond).
+		Build()
+
+	for i := 0; i < 3; i++ {
+		client.EventManager().DispatchEvent(&testEvent{n: i})
+	}
+
+	result := collector.Collect(context.Background())
+	assert.Equal(t, EventCollectorEndReasonIdleTimeout, result.Reason)
+	assert.Len(t, result.Events, 1)
+	assert.Equal(t, 2, result.Dropped)
+}
+
+func TestEventCollectorTimeout(t *testing.T) {
+	client := newTestClient()
+	result := NewEventCollectorBuilder[*testEvent](client).
+		SetTimeout(20 * time.Millisecond).
+		Collect(context.Background())
+	assert.Equal(t, EventCollectorEndReasonTimeout, result.Reason)
+	assert.Empty(t, result.Events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = NewEventCollectorBuilder[*testEvent](client).Collect(ctx)
+	assert.Equal(t, EventCollectorEndReasonStopped, result.Reason)
+}
@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ListenerOpt is used to configure the registration of an EventListener.
+type ListenerOpt func(config *listenerConfig)
+
+type listenerConfig struct {
+	priority int
+}
+
+// WithListenerPriority sets the priority of an EventListener. Listeners with a higher priority are called first.
+// Listeners with the same priority are called in the order they were added. The default priority is 0.
+func WithListenerPriority(priority int) ListenerOpt {
+	return func(config *listenerConfig) {
+		config.priority = priority
+	}
+}
+
+// ListenerHandle is returned when adding an EventListener and can be used to remove it again.
+type ListenerHandle struct {
+	manager      *eventManagerImpl
+	registration *listenerRegistration
+	once         sync.Once
+}
+
+// Remove removes the EventListener from the EventManager. Calling Remove multiple times is a no-op.
+func (h *ListenerHandle) Remove() {
+	h.once.Do(func() {
+		h.manager.removeRegistrations(func(registration *listenerRegistration) bool {
+			return registration == h.registration
+		})
+	})
+}
+
+// AddListenerFunc adds the given func(e E) to the EventManager and returns a ListenerHandle to remove it.
+func AddListenerFunc[E Event](manager EventManager, f func(e E), opts ...ListenerOpt) *ListenerHandle {
+	return manager.AddListener(NewListenerFunc(f), opts...)
+}
+
+// NewStoppableListenerFunc returns a new EventListener for the given func(e E) bool.
+// If the func returns true, listeners with a lower priority are not called for the event.
+// Stopping has no effect if AsyncEventsEnabled is set.
+func NewStoppableListenerFunc[E Event](f func(e E) bool) EventListener {
+	return &stoppableListenerFunc[E]{f: f}
+}
+
+type stoppableListenerFunc[E Event] struct {
+	f func(e E) bool
+}
+
+func (l *stoppableListenerFunc[E]) OnEvent(e Event) {
+	l.onEventStop(e)
+}
+
+func (l *stoppableListenerFunc[E]) onEventStop(e Event) bool {
+	if event, ok := e.(E); ok {
+		return l.f(event)
+	}
+	return false
+}
+
+func (l *stoppableListenerFunc[E]) eventType() reflect.Type {
+	return eventTypeOf[E]()
+}
+
+// stoppableListener is implemented by EventListener(s) which can stop the propagation of an event.
+type stoppableListener interface {
+	onEventStop(e Event) bool
+}
+
+// typedListener is implemented by EventListener(s) which only handle a single event type.
+type typedListener interface {
+	eventType() reflect.Type
+}
+
+// eventTypeOf returns the concrete type of E or nil if E is an interface and may match multiple event types.
+func eventTypeOf[E Event]() reflect.Type {
+	eventType := reflect.TypeOf((*E)(nil)).Elem()
+	if eventType.Kind() == reflect.Interface {
+		return nil
+	}
+	return eventType
+}
+
+type listenerRegistration struct {
+	listener EventListener
+	priority int
+	// order keeps the insertion order of listeners with the same priority
+	order uint64
+	// eventType is nil if the listener may handle any event
+	eventType reflect.Type
+}
+
+func (r *listenerRegistration) before(other *listenerRegistration) bool {
+	if r.priority != other.priority {
+		return r.priority > other.priority
+	}
+	return r.order < other.order
+}
+
+// call calls the listener and returns whether the propagation of the event should be stopped.
+func (r *listenerRegistration) call(event Event) bool {
+	if listener, ok := r.listener.(stoppableListener); ok {
+		return listener.onEventStop(event)
+	}
+	r.listener.OnEvent(event)
+	return false
+}
+
+// listenerRegistry is an immutable index of all listeners. It is replaced whenever a listener is added or removed.
+type listenerRegistry struct {
+	// concrete event type -> listeners sorted by priority
+	byType map[reflect.Type][]*listenerRegistration
+	// listeners without a concrete event type sorted by priority
+	wildcard []*listenerRegistration
+}
+
+func newListenerRegistry(registrations []*listenerRegistration) *listenerRegistry {
+	sorted := make([]*listenerRegistration, len(registrations))
+	copy(sorted, registrations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].before(sorted[j])
+	})
+
+	registry := &listenerRegistry{
+		byType: map[reflect.Type][]*listenerRegistration{},
+	}
+	for _, registration := range sorted {
+		if registration.eventType == nil {
+			registry.wildcard = append(registry.wildcard, registration)
+			continue
+		}
+		registry.byType[registration.eventType] = append(registry.byType[registration.eventType], registration)
+	}
+	return registry
+}
+
+// listeners calls f with all listeners which might handle the given event in priority order until f returns false.
+func (r *listenerRegistry) listeners(event Event, f func(registration *listenerRegistration) bool) {
+	typed, wildcard := r.byType[reflect.TypeOf(event)], r.wildcard
+	for len(typed) > 0 || len(wildcard) > 0 {
+		var registration *listenerRegistration
+		if len(wildcard) == 0 || (len(typed) > 0 && typed[0].before(wildcard[0])) {
+			registration, typed = typed[0], typed[1:]
+		} else {
+			registration, wildcard = wildcard[0], wildcard[1:]
+		}
+		if !f(registration) {
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOtherEvent struct{}
+
+func (*testOtherEvent) Client() Client      { return nil }
+func (*testOtherEvent) SequenceNumber() int { return 0 }
+
+func TestEventManagerListenerPriority(t *testing.T) {
+	var calls []string
+	e := NewEventManager(nil, WithListenerFunc(func(e *testEvent) {
+		calls = append(calls, "config")
+	}))
+	AddListenerFunc(e, func(e *testEvent) {
+		calls = append(calls, "low")
+	}, WithListenerPriority(-1))
+	AddListenerFunc(e, func(e *testEvent) {
+		calls = append(calls, "high")
+	}, WithListenerPriority(1))
+	e.AddEventListeners(NewListenerFunc(func(e Event) {
+		calls = append(calls, "any")
+	}))
+
+	e.DispatchEvent(&testEvent{})
+	assert.Equal(t, []string{"high", "config", "any", "low"}, calls)
+
+	calls = nil
+	e.DispatchEvent(&testOtherEvent{})
+	assert.Equal(t, []string{"any"}, calls)
+}
+
+func TestEventManagerListenerStopPropagation(t *testing.T) {
+	var calls []string
+	e := NewEventManager(nil)
+	AddListenerFunc(e, func(e *testEvent) {
+		calls = append(calls, "low")
+	})
+	e.AddListener(NewStoppableListenerFunc(func(e *testEvent) bool {
+		calls = append(calls, "high")
+		return e.n == 1
+	}), WithListenerPriority(1))
+
+	e.DispatchEvent(&testEvent{n: 0})
+	e.DispatchEvent(&testEvent{n: 1})
+	assert.Equal(t, []string{"high", "low", "high"}, calls)
+}
+
+func TestEventManagerListenerHandle(t *testing.T) {
+	var calls int
+	e := NewEventManager(nil)
+	handle := AddListenerFunc(e, func(e *testEvent) {
+		calls++
+	})
+
+	e.DispatchEvent(&testEvent{})
+	handle.Remove()
+	handle.Remove()
+	e.DispatchEvent(&testEvent{})
+	assert.Equal(t, 1, calls)
+}
@@ -1,8 +1,11 @@
 package bot
 
 import (
+	"context"
+	"reflect"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
@@ -15,10 +18,18 @@ func NewEventManager(client Client, opts ...EventManagerConfigOpt) EventManager
 	config := DefaultEventManagerConfig()
 	config.Apply(opts)
 
-	return &eventManagerImpl{
+	e := &eventManagerImpl{
 		client: client,
 		config: *config,
 	}
+	for _, listener := range config.EventListeners {
+		e.registrations = append(e.registrations, e.newRegistration(listener, nil))
+	}
+	e.registry.Store(newListenerRegistry(e.registrations))
+	if config.WorkerPool != nil {
+		e.workerPool = newEventWorkerPool(*config.WorkerPool, e.dispatch)
+	}
+	return e
 }
 
 // EventManager lets you listen for specific events triggered by raw gateway events
@@ -26,6 +37,10 @@ type EventManager interface {
 	// AddEventListeners adds one or more EventListener(s) to the EventManager
 	AddEventListeners(eventListeners ...EventListener)
 
+	// AddListener adds the EventListener with the given ListenerOpt(s) to the EventManager.
+	// The returned ListenerHandle can be used to remove the EventListener again.
+	AddListener(eventListener EventListener, opts ...ListenerOpt) *ListenerHandle
+
 	// RemoveEventListeners removes one or more EventListener(s) from the EventManager
 	RemoveEventListeners(eventListeners ...EventListener)
 
@@ -37,6 +52,12 @@ type EventManager interface {
 
 	// DispatchEvent dispatches a new Event to the Client's EventListener(s)
 	DispatchEvent(event Event)
+
+	// QueueMetrics returns the EventQueueMetrics of every worker if the worker pool is enabled or nil otherwise.
+	QueueMetrics() []EventQueueMetrics
+
+	// Close waits until all queued events are dispatched or the context is done.
+	Close(ctx context.Context)
 }
 
 // EventListener is used to create new EventListener to listen to events
@@ -59,6 +80,10 @@ func (l *listenerFunc[E]) OnEvent(e Event) {
 	}
 }
 
+func (l *listenerFunc[E]) eventType() reflect.Type {
+	return eventTypeOf[E]()
+}
+
 // NewListenerChan returns a new EventListener for the given chan<- Event
 func NewListenerChan[E Event](c chan<- E) EventListener {
 	return &listenerChan[E]{c: c}
@@ -74,6 +99,10 @@ func (l *listenerChan[E]) OnEvent(e Event) {
 	}
 }
 
+func (l *listenerChan[E]) eventType() reflect.Type {
+	return eventTypeOf[E]()
+}
+
 // Event the basic interface each event implement
 type Event interface {
 	Client() Client
@@ -114,7 +143,13 @@ type HTTPServerEventHandler interface {
 type eventManagerImpl struct {
 	client          Client
 	eventListenerMu sync.Mutex
-	config          EventManagerConfig
+	// registrations in the order they were added, guarded by eventListenerMu
+	registrations     []*listenerRegistration
+	registrationOrder uint64
+	// registry holds the current *listenerRegistry which is read without locking on dispatch
+	registry   atomic.Value
+	config     EventManagerConfig
+	workerPool *eventWorkerPool
 
 	mu sync.Mutex
 }
@@ -136,15 +171,36 @@ func (e *eventManagerImpl) HandleHTTPEvent(respondFunc httpserver.RespondFunc, e
 }
 
 func (e *eventManagerImpl) DispatchEvent(event Event) {
+	if e.workerPool != nil {
+		e.workerPool.submit(event)
+		return
+	}
+	e.dispatch(event)
+}
+
+func (e *eventManagerImpl) QueueMetrics() []EventQueueMetrics {
+	if e.workerPool == nil {
+		return nil
+	}
+	return e.workerPool.metrics()
+}
+
+func (e *eventManagerImpl) Close(ctx context.Context) {
+	if e.workerPool != nil {
+		e.workerPool.close(ctx)
+	}
+}
+
+func (e *eventManagerImpl) dispatch(event Event) {
 	defer func() {
 		if r := recover(); r != nil {
 			e.config.Logger.Errorf("recovered from panic in event listener: %+v\nstack: %s", r, string(debug.Stack()))
 			return
 		}
 	}()
-	e.eventListenerMu.Lock()
-	defer e.eventListenerMu.Unlock()
-	for i := range e.config.EventListeners {
+	// the registry is immutable, so listeners can add or remove listeners themselves
+	registry := e.registry.Load().(*listenerRegistry)
+	registry.listeners(event, func(registration *listenerRegistration) bool {
 		if e.config.AsyncEventsEnabled {
 			go func() {
 				defer func() {
@@ -153,29 +209,73 @@ func (e *eventManagerImpl) DispatchEvent(event Event) {
 						return
 					}
 				}()
-				e.config.EventListeners[i].OnEvent(event)
+				registration.listener.OnEvent(event)
 			}()
-			continue
+			return true
 		}
-		e.config.EventListeners[i].OnEvent(event)
+		return !registration.call(event)
+	})
+}
+
+// newRegistration creates a new listenerRegistration. eventListenerMu must be held.
+func (e *eventManagerImpl) newRegistration(listener EventListener, opts []ListenerOpt) *listenerRegistration {
+	config := &listenerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	registration := &listenerRegistration{
+		listener: listener,
+		priority: config.priority,
+		order:    e.registrationOrder,
+	}
+	e.registrationOrder++
+	if typed, ok := listener.(typedListener); ok {
+		registration.eventType = typed.eventType()
 	}
+	return registration
 }
 
-func (e *eventManagerImpl) AddEventListeners(listeners ...EventListener) {
+func (e *eventManagerImpl) AddListener(listener EventListener, opts ...ListenerOpt) *ListenerHandle {
 	e.eventListenerMu.Lock()
 	defer e.eventListenerMu.Unlock()
-	e.config.EventListeners = append(e.config.EventListeners, listeners...)
+	registration := e.newRegistration(listener, opts)
+	e.registrations = append(e.registrations, registration)
+	e.registry.Store(newListenerRegistry(e.registrations))
+	return &ListenerHandle{manager: e, registration: registration}
 }
 
-func (e *eventManagerImpl) RemoveEventListeners(listeners ...EventListener) {
+func (e *eventManagerImpl) AddEventListeners(listeners ...EventListener) {
 	e.eventListenerMu.Lock()
 	defer e.eventListenerMu.Unlock()
 	for _, listener := range listeners {
-		for i, l := range e.config.EventListeners {
-			if l == listener {
-				e.config.EventListeners = append(e.config.EventListeners[:i], e.config.EventListeners[i+1:]...)
-				break
+		e.registrations = append(e.registrations, e.newRegistration(listener, nil))
+	}
+	e.registry.Store(newListenerRegistry(e.registrations))
+}
+
+func (e *eventManagerImpl) RemoveEventListeners(listeners ...EventListener) {
+	for _, listener := range listeners {
+		removed := false
+		e.removeRegistrations(func(registration *listenerRegistration) bool {
+			if removed || registration.listener != listener {
+				return false
 			}
+			removed = true
+			return true
+		})
+	}
+}
+
+// removeRegistrations removes all listenerRegistration(s) for which remove returns true.
+func (e *eventManagerImpl) removeRegistrations(remove func(registration *listenerRegistration) bool) {
+	e.eventListenerMu.Lock()
+	defer e.eventListenerMu.Unlock()
+	registrations := make([]*listenerRegistration, 0, len(e.registrations))
+	for _, registration := range e.registrations {
+		if !remove(registration) {
+			registrations = append(registrations, registration)
 		}
 	}
+	e.registrations = registrations
+	e.registry.Store(newListenerRegistry(e.registrations))
 }
@@ -18,6 +18,7 @@ type EventManagerConfig struct {
 	Logger             log.Logger
 	EventListeners     []EventListener
 	AsyncEventsEnabled bool
+	WorkerPool         *EventWorkerPoolConfig
 
 	GatewayHandlers   map[gateway.EventType]GatewayEventHandler
 	HTTPServerHandler HTTPServerEventHandler
@@ -64,6 +65,16 @@ func WithAsyncEventsEnabled() EventManagerConfigOpt {
 	}
 }
 
+// WithEventWorkerPool dispatches events through a bounded worker pool instead of the goroutine receiving them.
+// Events with the same key (by default the guild or channel id) keep their order. See EventWorkerPoolConfig for more information.
+func WithEventWorkerPool(opts ...EventWorkerPoolConfigOpt) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		workerPoolConfig := DefaultEventWorkerPoolConfig()
+		workerPoolConfig.Apply(opts)
+		config.WorkerPool = workerPoolConfig
+	}
+}
+
 // WithGatewayHandlers overrides the default GatewayEventHandler(s) in the EventManagerConfig.
 func WithGatewayHandlers(handlers map[gateway.EventType]GatewayEventHandler) EventManagerConfigOpt {
 	return func(config *EventManagerConfig) {
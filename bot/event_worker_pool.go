@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EventQueueMetrics holds the metrics of the queue of a single worker of the EventManager worker pool.
+type EventQueueMetrics struct {
+	Worker     int
+	Depth      int
+	Capacity   int
+	Dispatched uint64
+	Dropped    uint64
+}
+
+var (
+	idType    = reflect.TypeOf(snowflake.ID(0))
+	idPtrType = reflect.TypeOf((*snowflake.ID)(nil))
+
+	// reflect.Type -> func(reflect.Value) (uint64, bool)
+	eventKeyFuncs sync.Map
+)
+
+// DefaultEventKey returns the guild id of the event, or the channel id if it has no guild id, or the shard id if it has neither.
+// This keeps the order of all events of the same guild or channel.
+func DefaultEventKey(event Event) uint64 {
+	v := reflect.ValueOf(event)
+	keyFunc, ok := eventKeyFuncs.Load(v.Type())
+	if !ok {
+		keyFunc, _ = eventKeyFuncs.LoadOrStore(v.Type(), newEventKeyFunc(v.Type()))
+	}
+	if key, ok := keyFunc.(func(reflect.Value) (uint64, bool))(v); ok {
+		return key
+	}
+	if shardEvent, ok := event.(interface{ ShardID() int }); ok {
+		return uint64(shardEvent.ShardID())
+	}
+	return 0
+}
+
+// newEventKeyFunc looks up the GuildID & ChannelID fields of the event type once, so they don't need to be searched for every event.
+func newEventKeyFunc(t reflect.Type) func(reflect.Value) (uint64, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var fields [][]int
+	if t.Kind() == reflect.Struct {
+		for _, name := range []string{"GuildID", "ChannelID"} {
+			if field, ok := t.FieldByName(name); ok && (field.Type == idType || field.Type == idPtrType) {
+				fields = append(fields, field.Index)
+			}
+		}
+	}
+
+	return func(v reflect.Value) (uint64, bool) {
+		v = reflect.Indirect(v)
+		for _, index := range fields {
+			field, err := v.FieldByIndexErr(index)
+			if err != nil {
+				// embedded struct is nil
+				continue
+			}
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					continue
+				}
+				field = field.Elem()
+			}
+			if id := field.Uint(); id != 0 {
+				return id, true
+			}
+		}
+		return 0, false
+	}
+}
+
+func newEventWorkerPool(config EventWorkerPoolConfig, dispatch func(event Event)) *eventWorkerPool {
+	p := &eventWorkerPool{
+		config:   config,
+		dispatch: dispatch,
+		queues:   make([]*eventQueue, config.Workers),
+		closing:  make(chan struct{}),
+	}
+	for i := range p.queues {
+		q := &eventQueue{events: make(chan Event, config.QueueSize)}
+		p.queues[i] = q
+		p.wg.Add(1)
+		go p.work(q)
+	}
+	return p
+}
+
+type eventWorkerPool struct {
+	config   EventWorkerPoolConfig
+	dispatch func(event Event)
+	queues   []*eventQueue
+	wg       sync.WaitGroup
+
+	// closing is closed once the pool stops accepting events. It also releases submitters blocked on a full queue.
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+type eventQueue struct {
+	events     chan Event
+	mu         sync.Mutex
+	dispatched uint64
+	dropped    uint64
+}
+
+func (p *eventWorkerPool) work(q *eventQueue) {
+	defer p.wg.Done()
+	for {
+		select {
+		case event := <-q.events:
+			p.dispatchQueued(q, event)
+		case <-p.closing:
+			// dispatch the remaining events
+			for {
+				select {
+				case event := <-q.events:
+					p.dispatchQueued(q, event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *eventWorkerPool) dispatchQueued(q *eventQueue, event Event) {
+	p.dispatch(event)
+	atomic.AddUint64(&q.dispatched, 1)
+}
+
+func (p *eventWorkerPool) submit(event Event) {
+	select {
+	case <-p.closing:
+		return
+	default:
+	}
+
+	// spread snowflakes evenly as their lower bits are mostly the same
+	q := p.queues[(p.config.KeyFunc(event)*0x9E3779B97F4A7C15>>32)%uint64(len(p.queues))]
+	switch p.config.DropPolicy {
+	case EventDropPolicyDropNewest:
+		select {
+		case q.events <- event:
+		default:
+			atomic.AddUint64(&q.dropped, 1)
+		}
+
+	case EventDropPolicyDropOldest:
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		for {
+			select {
+			case q.events <- event:
+				return
+			default:
+			}
+			select {
+			case <-q.events:
+				atomic.AddUint64(&q.dropped, 1)
+			default:
+			}
+		}
+
+	default:
+		select {
+		case q.events <- event:
+		case <-p.closing:
+		}
+	}
+}
+
+func (p *eventWorkerPool) metrics() []EventQueueMetrics {
+	metrics := make([]EventQueueMetrics, len(p.queues))
+	for i, q := range p.queues {
+		metrics[i] = EventQueueMetrics{
+			Worker:     i,
+			Depth:      len(q.events),
+			Capacity:   cap(q.events),
+			Dispatched: atomic.LoadUint64(&q.dispatched),
+			Dropped:    atomic.LoadUint64(&q.dropped),
+		}
+	}
+	return metrics
+}
+
+// close stops accepting new events and waits until all queued events are dispatched or the context is done.
+// Events which are submitted while closing might not be dispatched.
+func (p *eventWorkerPool) close(ctx context.Context) {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-ctx.Done():
+	case <-done:
+	}
+}
@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"runtime"
+)
+
+// EventDropPolicy decides what happens to an event if the queue of its worker is full.
+type EventDropPolicy int
+
+const (
+	// EventDropPolicyBlock blocks the dispatching goroutine until the queue has space again. This applies backpressure to the gateway.
+	// Calling EventManager.DispatchEvent from a listener blocks forever if the event goes to the full queue of the worker running the listener,
+	// so dispatch such events from a new goroutine or use another EventDropPolicy.
+	EventDropPolicyBlock EventDropPolicy = iota
+	// EventDropPolicyDropNewest drops the event which should be queued.
+	EventDropPolicyDropNewest
+	// EventDropPolicyDropOldest drops the oldest queued event to make space for the new one.
+	// With a queue size of 0 there is no queued event to drop, so EventDropPolicyDropNewest is used instead.
+	EventDropPolicyDropOldest
+)
+
+// DefaultEventWorkerPoolConfig returns a EventWorkerPoolConfig with sensible defaults.
+func DefaultEventWorkerPoolConfig() *EventWorkerPoolConfig {
+	return &EventWorkerPoolConfig{
+		Workers:    runtime.NumCPU(),
+		QueueSize:  100,
+		DropPolicy: EventDropPolicyBlock,
+		KeyFunc:    DefaultEventKey,
+	}
+}
+
+// EventWorkerPoolConfig lets you configure the worker pool of the EventManager.
+type EventWorkerPoolConfig struct {
+	Workers    int
+	QueueSize  int
+	DropPolicy EventDropPolicy
+	KeyFunc    func(event Event) uint64
+}
+
+// EventWorkerPoolConfigOpt is a type alias for a function that takes a EventWorkerPoolConfig and is used to configure the worker pool.
+type EventWorkerPoolConfigOpt func(config *EventWorkerPoolConfig)
+
+// Apply applies the given EventWorkerPoolConfigOpt(s) to the EventWorkerPoolConfig
+func (c *EventWorkerPoolConfig) Apply(opts []EventWorkerPoolConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Workers < 1 {
+		c.Workers = 1
+	}
+	if c.QueueSize < 0 {
+		c.QueueSize = 0
+	}
+	if c.QueueSize == 0 && c.DropPolicy == EventDropPolicyDropOldest {
+		c.DropPolicy = EventDropPolicyDropNewest
+	}
+}
+
+// WithEventWorkers sets how many workers dispatch events concurrently. Every worker has its own queue.
+func WithEventWorkers(workers int) EventWorkerPoolConfigOpt {
+	return func(config *EventWorkerPoolConfig) {
+		config.Workers = workers
+	}
+}
+
+// WithEventQueueSize sets how many events can be queued per worker. A queue size of 0 hands events directly to an idle worker.
+func WithEventQueueSize(queueSize int) EventWorkerPoolConfigOpt {
+	return func(config *EventWorkerPoolConfig) {
+		config.QueueSize = queueSize
+	}
+}
+
+// WithEventDropPolicy sets the EventDropPolicy used when the queue of a worker is full.
+func WithEventDropPolicy(dropPolicy EventDropPolicy) EventWorkerPoolConfigOpt {
+	return func(config *EventWorkerPoolConfig) {
+		config.DropPolicy = dropPolicy
+	}
+}
+
+// WithEventKeyFunc sets the func which returns the partition key of an event.
+// Events with the same key are dispatched by the same worker in the order they were received.
+func WithEventKeyFunc(keyFunc func(event Event) uint64) EventWorkerPoolConfigOpt {
+	return func(config *EventWorkerPoolConfig) {
+		config.KeyFunc = keyFunc
+	}
+}
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type testGenericEvent struct {
+	ChannelID snowflake.ID
+}
+
+type testEvent struct {
+	*testGenericEvent
+	GuildID *snowflake.ID
+	n       int
+}
+
+func (*testEvent) Client() Client      { return nil }
+func (*testEvent) SequenceNumber() int { return 0 }
+
+func TestDefaultEventKey(t *testing.T) {
+	guildID := snowflake.ID(123)
+	assert.Equal(t, uint64(123), DefaultEventKey(&testEvent{GuildID: &guildID, testGenericEvent: &testGenericEvent{ChannelID: 456}}))
+	assert.Equal(t, uint64(456), DefaultEventKey(&testEvent{testGenericEvent: &testGenericEvent{ChannelID: 456}}))
+	assert.Equal(t, uint64(0), DefaultEventKey(&testEvent{}))
+}
+
+func TestEventWorkerPoolOrder(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		orders = map[snowflake.ID][]int{}
+	)
+	e := NewEventManager(nil, WithEventWorkerPool(WithEventWorkers(4), WithEventQueueSize(10)), WithListenerFunc(func(e *testEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		orders[*e.GuildID] = append(orders[*e.GuildID], e.n)
+	}))
+
+	for i := 0; i < 100; i++ {
+		for guildID := snowflake.ID(1); guildID <= 8; guildID++ {
+			id := guildID
+			e.DispatchEvent(&testEvent{GuildID: &id, n: i})
+		}
+	}
+	e.Close(context.Background())
+
+	for _, metrics := range e.QueueMetrics() {
+		assert.Zero(t, metrics.Depth)
+		assert.Zero(t, metrics.Dropped)
+	}
+	for guildID, order := range orders {
+		assert.Len(t, order, 100, "guild %d", guildID)
+		for i, n := range order {
+			assert.Equal(t, i, n)
+		}
+	}
+}
+
+func TestEventWorkerPoolDropNewest(t *testing.T) {
+	started, block := make(chan struct{}, 10), make(chan struct{})
+	e := NewEventManager(nil, WithEventWorkerPool(WithEventWorkers(1), WithEventQueueSize(1), WithEventDropPolicy(EventDropPolicyDropNewest)), WithListenerFunc(func(e *testEvent) {
+		started <- struct{}{}
+		<-block
+	}))
+
+	e.DispatchEvent(&testEvent{n: 0})
+	<-started
+	for i := 1; i < 10; i++ {
+		e.DispatchEvent(&testEvent{n: i})
+	}
+	metrics := e.QueueMetrics()[0]
+	// one event is dispatched, one is queued
+	assert.Equal(t, uint64(8), metrics.Dropped)
+	close(block)
+	e.Close(context.Background())
+	assert.Equal(t, uint64(2), e.QueueMetrics()[0].Dispatched)
+}
+
+func TestEventWorkerPoolNegativeQueueSize(t *testing.T) {
+	e := NewEventManager(nil, WithEventWorkerPool(WithEventQueueSize(-1)))
+	defer e.Close(context.Background())
+	assert.Zero(t, e.QueueMetrics()[0].Capacity)
+}
+
+func TestEventWorkerPoolDropOldestWithoutQueue(t *testing.T) {
+	started, block := make(chan struct{}, 1), make(chan struct{})
+	e := NewEventManager(nil, WithEventWorkerPool(WithEventWorkers(1), WithEventQueueSize(0), WithEventDropPolicy(EventDropPolicyDropOldest)), WithListenerFunc(func(e *testEvent) {
+		started <- struct{}{}
+		<-block
+	}))
+
+	// the event is dropped until the worker waits for it
+	assert.Eventually(t, func() bool {
+		e.DispatchEvent(&testEvent{n: 0})
+		select {
+		case <-started:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+	dropped := e.QueueMetrics()[0].Dropped
+
+	// there is no queued event to drop, so the new event is dropped instead of spinning
+	e.DispatchEvent(&testEvent{n: 1})
+	assert.Equal(t, dropped+1, e.QueueMetrics()[0].Dropped)
+	close(block)
+	e.Close(context.Background())
+}
+
+func TestEventWorkerPoolCloseWithBlockedSubmitter(t *testing.T) {
+	started, block := make(chan struct{}, 1), make(chan struct{})
+	defer close(block)
+	e := NewEventManager(nil, WithEventWorkerPool(WithEventWorkers(1), WithEventQueueSize(1)), WithListenerFunc(func(e *testEvent) {
+		if e.n == 0 {
+			started <- struct{}{}
+			<-block
+		}
+	}))
+
+	e.DispatchEvent(&testEvent{n: 0})
+	<-started
+	e.DispatchEvent(&testEvent{n: 1})
+	submitted := make(chan struct{})
+	go func() {
+		defer close(submitted)
+		// blocks as the queue is full
+		e.DispatchEvent(&testEvent{n: 2})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	e.Close(ctx)
+	select {
+	case <-submitted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked submitter was not released by close")
+	}
+}
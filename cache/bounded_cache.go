@@ -0,0 +1,374 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EvictReason describes why an entity got evicted from a bounded GroupedCache.
+type EvictReason int
+
+const (
+	// EvictReasonGroupSize means the group of the entity exceeded the BoundedCacheConfig.MaxGroupSize.
+	EvictReasonGroupSize EvictReason = iota
+	// EvictReasonSize means the cache exceeded the BoundedCacheConfig.MaxSize.
+	EvictReasonSize
+	// EvictReasonExpired means the entity was put longer than the BoundedCacheConfig.TTL ago.
+	EvictReasonExpired
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonGroupSize:
+		return "group size"
+	case EvictReasonSize:
+		return "size"
+	case EvictReasonExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictFunc is called for every entity which got evicted from a bounded GroupedCache.
+// It is not called for entities removed via Remove, GroupRemove, RemoveIf or GroupRemoveIf.
+type EvictFunc[T any] func(groupID snowflake.ID, id snowflake.ID, entity T, reason EvictReason)
+
+// EvictNotifier is implemented by caches which remove entities on their own, like the bounded GroupedCache.
+type EvictNotifier[T any] interface {
+	// AddEvictFunc adds an EvictFunc which is called for every evicted entity.
+	AddEvictFunc(evictFunc EvictFunc[T])
+}
+
+var (
+	_ GroupedCache[any]  = (*boundedGroupedCache[any])(nil)
+	_ StatsProvider      = (*boundedGroupedCache[any])(nil)
+	_ EvictNotifier[any] = (*boundedGroupedCache[any])(nil)
+)
+
+// NewBoundedGroupedCache returns a new thread safe GroupedCache which evicts the least recently used entities once the configured limits are reached.
+// Entities expire once the configured TTL passed since they were put. The optional onEvict func is called for every evicted entity.
+//
+//	cache.WithMessageCache(cache.NewMessageCache(cache.NewBoundedGroupedCache[discord.Message](flags, cache.FlagMessages, nil, nil, cache.WithMaxGroupSize(50))))
+func NewBoundedGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], onEvict EvictFunc[T], opts ...BoundedCacheConfigOpt) GroupedCache[T] {
+	config := DefaultBoundedCacheConfig()
+	config.Apply(opts)
+
+	c := &boundedGroupedCache[T]{
+		config:      *config,
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		cache:       make(map[snowflake.ID]*boundedGroup[T]),
+		lru:         list.New(),
+		expiry:      list.New(),
+	}
+	if onEvict != nil {
+		c.evictFuncs = append(c.evictFuncs, onEvict)
+	}
+	return c
+}
+
+type boundedEntry[T any] struct {
+	groupID    snowflake.ID
+	id         snowflake.ID
+	entity     T
+	expiresAt  time.Time
+	lruElem    *list.Element
+	groupElem  *list.Element
+	expiryElem *list.Element
+}
+
+type boundedGroup[T any] struct {
+	entries map[snowflake.ID]*boundedEntry[T]
+	lru     *list.List
+}
+
+type evictedEntry[T any] struct {
+	entry  *boundedEntry[T]
+	reason EvictReason
+}
+
+type boundedGroupedCache[T any] struct {
+	cacheCounters
+	mu          sync.Mutex
+	config      BoundedCacheConfig
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	evictFuncs  []EvictFunc[T]
+	cache       map[snowflake.ID]*boundedGroup[T]
+	// lru contains all entries with the most recently used at the front
+	lru *list.List
+	// expiry contains all entries with the one expiring first at the front
+	expiry *list.List
+}
+
+func (c *boundedGroupedCache[T]) expired(entry *boundedEntry[T], now time.Time) bool {
+	return c.config.TTL > 0 && !now.Before(entry.expiresAt)
+}
+
+func (c *boundedGroupedCache[T]) touch(entry *boundedEntry[T]) {
+	c.lru.MoveToFront(entry.lruElem)
+	c.cache[entry.groupID].lru.MoveToFront(entry.groupElem)
+}
+
+func (c *boundedGroupedCache[T]) remove(entry *boundedEntry[T]) {
+	c.lru.Remove(entry.lruElem)
+	c.expiry.Remove(entry.expiryElem)
+	group := c.cache[entry.groupID]
+	group.lru.Remove(entry.groupElem)
+	delete(group.entries, entry.id)
+	if len(group.entries) == 0 {
+		delete(c.cache, entry.groupID)
+	}
+}
+
+// evictExpired removes all expired entries. As all entries have the same TTL, the expiry list is ordered by the time they were put.
+func (c *boundedGroupedCache[T]) evictExpired(now time.Time, evicted []evictedEntry[T]) []evictedEntry[T] {
+	if c.config.TTL <= 0 {
+		return evicted
+	}
+	for elem := c.expiry.Front(); elem != nil; elem = c.expiry.Front() {
+		entry := elem.Value.(*boundedEntry[T])
+		if !c.expired(entry, now) {
+			break
+		}
+		c.remove(entry)
+		evicted = append(evicted, evictedEntry[T]{entry: entry, reason: EvictReasonExpired})
+	}
+	return evicted
+}
+
+func (c *boundedGroupedCache[T]) AddEvictFunc(evictFunc EvictFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictFuncs = append(c.evictFuncs, evictFunc)
+}
+
+func (c *boundedGroupedCache[T]) callOnEvict(evicted []evictedEntry[T]) {
+	if len(evicted) == 0 {
+		return
+	}
+	c.countEvictions(len(evicted))
+	c.mu.Lock()
+	evictFuncs := c.evictFuncs
+	c.mu.Unlock()
+
+	for _, e := range evicted {
+		for _, evictFunc := range evictFuncs {
+			evictFunc(e.entry.groupID, e.entry.id, e.entry.entity, e.reason)
+		}
+	}
+}
+
+func (c *boundedGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	evicted := c.evictExpired(c.config.Clock(), nil)
+
+	var (
+		entity T
+		ok     bool
+	)
+	if group, groupOk := c.cache[groupID]; groupOk {
+		if entry, entryOk := group.entries[id]; entryOk {
+			c.touch(entry)
+			entity, ok = entry.entity, true
+		}
+	}
+	c.mu.Unlock()
+
+	c.countGet(ok)
+	c.callOnEvict(evicted)
+	return entity, ok
+}
+
+func (c *boundedGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		c.countPolicyRejection()
+		return
+	}
+	c.mu.Lock()
+	now := c.config.Clock()
+	evicted := c.evictExpired(now, nil)
+
+	group, ok := c.cache[groupID]
+	if !ok {
+		group = &boundedGroup[T]{
+			entries: make(map[snowflake.ID]*boundedEntry[T]),
+			lru:     list.New(),
+		}
+		c.cache[groupID] = group
+	}
+
+	if entry, ok := group.entries[id]; ok {
+		entry.entity = entity
+		entry.expiresAt = now.Add(c.config.TTL)
+		c.touch(entry)
+		c.expiry.MoveToBack(entry.expiryElem)
+	} else {
+		entry = &boundedEntry[T]{
+			groupID:   groupID,
+			id:        id,
+			entity:    entity,
+			expiresAt: now.Add(c.config.TTL),
+		}
+		entry.lruElem = c.lru.PushFront(entry)
+		entry.groupElem = group.lru.PushFront(entry)
+		entry.expiryElem = c.expiry.PushBack(entry)
+		group.entries[id] = entry
+	}
+
+	if c.config.MaxGroupSize > 0 {
+		for group.lru.Len() > c.config.MaxGroupSize {
+			entry := group.lru.Back().Value.(*boundedEntry[T])
+			c.remove(entry)
+			evicted = append(evicted, evictedEntry[T]{entry: entry, reason: EvictReasonGroupSize})
+		}
+	}
+	if c.config.MaxSize > 0 {
+		for c.lru.Len() > c.config.MaxSize {
+			entry := c.lru.Back().Value.(*boundedEntry[T])
+			c.remove(entry)
+			evicted = append(evicted, evictedEntry[T]{entry: entry, reason: EvictReasonSize})
+		}
+	}
+	c.mu.Unlock()
+
+	c.callOnEvict(evicted)
+}
+
+func (c *boundedGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if group, ok := c.cache[groupID]; ok {
+		if entry, ok := group.entries[id]; ok {
+			c.remove(entry)
+			return entry.entity, !c.expired(entry, c.config.Clock())
+		}
+	}
+	var entity T
+	return entity, false
+}
+
+func (c *boundedGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if group, ok := c.cache[groupID]; ok {
+		for _, entry := range group.entries {
+			c.remove(entry)
+		}
+	}
+}
+
+func (c *boundedGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for groupID, group := range c.cache {
+		for _, entry := range group.entries {
+			if filterFunc(groupID, entry.entity) {
+				c.remove(entry)
+			}
+		}
+	}
+}
+
+func (c *boundedGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if group, ok := c.cache[groupID]; ok {
+		for _, entry := range group.entries {
+			if filterFunc(groupID, entry.entity) {
+				c.remove(entry)
+			}
+		}
+	}
+}
+
+func (c *boundedGroupedCache[T]) Len() int {
+	c.mu.Lock()
+	evicted := c.evictExpired(c.config.Clock(), nil)
+	length := c.lru.Len()
+	c.mu.Unlock()
+
+	c.callOnEvict(evicted)
+	return length
+}
+
+func (c *boundedGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	c.mu.Lock()
+	evicted := c.evictExpired(c.config.Clock(), nil)
+	var length int
+	if group, ok := c.cache[groupID]; ok {
+		length = len(group.entries)
+	}
+	c.mu.Unlock()
+
+	c.callOnEvict(evicted)
+	return length
+}
+
+func (c *boundedGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.config.Clock()
+	for groupID, group := range c.cache {
+		for _, entry := range group.entries {
+			if !c.expired(entry, now) {
+				forEachFunc(groupID, entry.entity)
+			}
+		}
+	}
+}
+
+func (c *boundedGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	group, ok := c.cache[groupID]
+	if !ok {
+		return
+	}
+	now := c.config.Clock()
+	for _, entry := range group.entries {
+		if !c.expired(entry, now) {
+			forEachFunc(entry.entity)
+		}
+	}
+}
+
+func (c *boundedGroupedCache[T]) Stats() CacheStats {
+	length := c.Len()
+	return c.stats(c.neededFlags, length, approxSize(length, c.sample(statsSampleSize)))
+}
+
+// sample returns up to n entities of the cache which are not expired.
+func (c *boundedGroupedCache[T]) sample(n int) []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	samples := make([]T, 0, n)
+	now := c.config.Clock()
+	for _, group := range c.cache {
+		for _, entry := range group.entries {
+			if len(samples) == n {
+				return samples
+			}
+			if !c.expired(entry, now) {
+				samples = append(samples, entry.entity)
+			}
+		}
+	}
+	return samples
+}
@@ -0,0 +1,59 @@
+package cache
+
+import "time"
+
+// DefaultBoundedCacheConfig returns a BoundedCacheConfig with sensible defaults.
+func DefaultBoundedCacheConfig() *BoundedCacheConfig {
+	return &BoundedCacheConfig{
+		MaxGroupSize: 100,
+		MaxSize:      10000,
+		Clock:        time.Now,
+	}
+}
+
+// BoundedCacheConfig lets you configure your bounded GroupedCache.
+// A value of 0 disables the respective limit.
+type BoundedCacheConfig struct {
+	MaxGroupSize int
+	MaxSize      int
+	TTL          time.Duration
+	Clock        func() time.Time
+}
+
+// BoundedCacheConfigOpt is a type alias for a function that takes a BoundedCacheConfig and is used to configure your bounded GroupedCache.
+type BoundedCacheConfigOpt func(config *BoundedCacheConfig)
+
+// Apply applies the given BoundedCacheConfigOpt(s) to the BoundedCacheConfig
+func (c *BoundedCacheConfig) Apply(opts []BoundedCacheConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithMaxGroupSize sets the maximum number of entities per group (for example per channel for messages).
+func WithMaxGroupSize(maxGroupSize int) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.MaxGroupSize = maxGroupSize
+	}
+}
+
+// WithMaxSize sets the maximum number of entities in the whole cache.
+func WithMaxSize(maxSize int) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.MaxSize = maxSize
+	}
+}
+
+// WithTTL sets after which duration since it was put an entity expires.
+func WithTTL(ttl time.Duration) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.TTL = ttl
+	}
+}
+
+// WithClock sets the func used to get the current time. This is mostly useful for tests.
+func WithClock(clock func() time.Time) BoundedCacheConfigOpt {
+	return func(config *BoundedCacheConfig) {
+		config.Clock = clock
+	}
+}
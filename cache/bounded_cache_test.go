@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedGroupedCache_Limits(t *testing.T) {
+	var evicted []snowflake.ID
+	c := NewBoundedGroupedCache[int](FlagsAll, FlagMessages, nil, func(_ snowflake.ID, id snowflake.ID, _ int, _ EvictReason) {
+		evicted = append(evicted, id)
+	}, WithMaxGroupSize(2), WithMaxSize(3))
+
+	c.Put(1, 10, 10)
+	c.Put(1, 11, 11)
+	// access 10 so 11 is the least recently used entry of group 1
+	c.Get(1, 10)
+	c.Put(1, 12, 12)
+	assert.Equal(t, []snowflake.ID{11}, evicted)
+	assert.Equal(t, 2, c.GroupLen(1))
+
+	c.Put(2, 20, 20)
+	c.Put(2, 21, 21)
+	assert.Equal(t, []snowflake.ID{11, 10}, evicted)
+	assert.Equal(t, 3, c.Len())
+
+	_, ok := c.Get(1, 10)
+	assert.False(t, ok)
+	_, ok = c.Get(1, 12)
+	assert.True(t, ok)
+}
+
+func TestBoundedGroupedCache_TTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	var reasons []EvictReason
+	c := NewBoundedGroupedCache[int](FlagsAll, FlagMessages, nil, func(_ snowflake.ID, _ snowflake.ID, _ int, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}, WithTTL(10*time.Second), WithClock(func() time.Time {
+		return now
+	}))
+
+	c.Put(1, 10, 10)
+	c.Put(1, 11, 11)
+
+	// accessing an entity does not extend its TTL
+	now = now.Add(5 * time.Second)
+	_, ok := c.Get(1, 10)
+	assert.True(t, ok)
+	// putting it again does
+	c.Put(1, 11, 11)
+
+	now = now.Add(5 * time.Second)
+	_, ok = c.Get(1, 10)
+	assert.False(t, ok)
+	_, ok = c.Get(1, 11)
+	assert.True(t, ok)
+	assert.Equal(t, []EvictReason{EvictReasonExpired}, reasons)
+
+	now = now.Add(5 * time.Second)
+	assert.Equal(t, 0, c.Len())
+}
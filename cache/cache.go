@@ -31,7 +31,10 @@ type Cache[T any] interface {
 	ForEach(func(entity T))
 }
 
-var _ Cache[any] = (*DefaultCache[any])(nil)
+var (
+	_ Cache[any]    = (*DefaultCache[any])(nil)
+	_ StatsProvider = (*DefaultCache[any])(nil)
+)
 
 // NewCache returns a new DefaultCache implementation which filter the entities after the gives Flags and Policy.
 // This cache implementation is thread safe and can be used in multiple goroutines without any issues.
@@ -47,6 +50,7 @@ func NewCache[T any](flags Flags, neededFLags Flags, policy Policy[T]) Cache[T]
 
 // DefaultCache is a simple thread safe cache key value store.
 type DefaultCache[T any] struct {
+	cacheCounters
 	mu          sync.RWMutex
 	flags       Flags
 	neededFLags Flags
@@ -58,6 +62,7 @@ func (c *DefaultCache[T]) Get(id snowflake.ID) (T, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	entity, ok := c.cache[id]
+	c.countGet(ok)
 	return entity, ok
 }
 
@@ -66,6 +71,7 @@ func (c *DefaultCache[T]) Put(id snowflake.ID, entity T) {
 		return
 	}
 	if c.policy != nil && !c.policy(entity) {
+		c.countPolicyRejection()
 		return
 	}
 	c.mu.Lock()
@@ -107,3 +113,23 @@ func (c *DefaultCache[T]) ForEach(forEachFunc func(entity T)) {
 		forEachFunc(entity)
 	}
 }
+
+func (c *DefaultCache[T]) Stats() CacheStats {
+	length := c.Len()
+	return c.stats(c.neededFLags, length, approxSize(length, c.sample(statsSampleSize)))
+}
+
+// sample returns up to n entities of the cache.
+func (c *DefaultCache[T]) sample(n int) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	samples := make([]T, 0, n)
+	for _, entity := range c.cache {
+		if len(samples) == n {
+			break
+		}
+		samples = append(samples, entity)
+	}
+	return samples
+}
@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"time"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -29,6 +31,12 @@ func DefaultConfig() *Config {
 type Config struct {
 	CacheFlags Flags
 
+	KVStore     KVStore
+	KVCacheOpts []KVCacheConfigOpt
+
+	TombstoneCache TombstoneCache
+	TombstoneTTL   time.Duration
+
 	SelfUserCache SelfUserCache
 
 	GuildCache       GuildCache
@@ -79,41 +87,76 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	if c.SelfUserCache == nil {
 		c.SelfUserCache = NewSelfUserCache()
 	}
+	if c.TombstoneCache == nil {
+		c.TombstoneCache = NewTombstoneCache(c.TombstoneTTL)
+	}
 	if c.GuildCache == nil {
-		c.GuildCache = NewGuildCache(NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy), set.New[snowflake.ID](), set.New[snowflake.ID]())
+		c.GuildCache = NewGuildCache(newCache(c, "guilds", FlagGuilds, c.GuildCachePolicy), set.New[snowflake.ID](), set.New[snowflake.ID]())
 	}
 	if c.ChannelCache == nil {
-		c.ChannelCache = NewChannelCache(NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy))
+		c.ChannelCache = NewChannelCache(newIndexedCache(c, "channels", FlagChannels, c.ChannelCachePolicy, func(channel discord.GuildChannel) snowflake.ID {
+			return channel.GuildID()
+		}))
 	}
 	if c.StageInstanceCache == nil {
-		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy))
+		c.StageInstanceCache = NewStageInstanceCache(newGroupedCache(c, "stage_instances", FlagStageInstances, c.StageInstanceCachePolicy))
 	}
 	if c.GuildScheduledEventCache == nil {
-		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
+		c.GuildScheduledEventCache = NewGuildScheduledEventCache(newGroupedCache(c, "guild_scheduled_events", FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
 	}
 	if c.RoleCache == nil {
-		c.RoleCache = NewRoleCache(NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy))
+		c.RoleCache = NewRoleCache(newGroupedCache(c, "roles", FlagRoles, c.RoleCachePolicy))
 	}
 	if c.MemberCache == nil {
-		c.MemberCache = NewMemberCache(NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy))
+		c.MemberCache = NewMemberCache(newGroupedCache(c, "members", FlagMembers, c.MemberCachePolicy))
 	}
 	if c.ThreadMemberCache == nil {
-		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy))
+		c.ThreadMemberCache = NewThreadMemberCache(newGroupedCache(c, "thread_members", FlagThreadMembers, c.ThreadMemberCachePolicy))
 	}
 	if c.PresenceCache == nil {
-		c.PresenceCache = NewPresenceCache(NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy))
+		c.PresenceCache = NewPresenceCache(newGroupedCache(c, "presences", FlagPresences, c.PresenceCachePolicy))
 	}
 	if c.VoiceStateCache == nil {
-		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy))
+		c.VoiceStateCache = NewVoiceStateCache(newGroupedCache(c, "voice_states", FlagVoiceStates, c.VoiceStateCachePolicy))
 	}
 	if c.MessageCache == nil {
-		c.MessageCache = NewMessageCache(NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy))
+		c.MessageCache = NewMessageCache(newGroupedCache(c, "messages", FlagMessages, c.MessageCachePolicy))
 	}
 	if c.EmojiCache == nil {
-		c.EmojiCache = NewEmojiCache(NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy))
+		c.EmojiCache = NewEmojiCache(newGroupedCache(c, "emojis", FlagEmojis, c.EmojiCachePolicy))
 	}
 	if c.StickerCache == nil {
-		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
+		c.StickerCache = NewStickerCache(newGroupedCache(c, "stickers", FlagStickers, c.StickerCachePolicy))
+	}
+}
+
+func newCache[T any](config *Config, namespace string, neededFlags Flags, policy Policy[T]) Cache[T] {
+	if config.KVStore != nil {
+		return NewKVCache[T](config.KVStore, namespace, nil, config.CacheFlags, neededFlags, policy, config.KVCacheOpts...)
+	}
+	return NewCache[T](config.CacheFlags, neededFlags, policy)
+}
+
+func newIndexedCache[T any](config *Config, namespace string, neededFlags Flags, policy Policy[T], indexFunc IndexFunc[T]) Cache[T] {
+	if config.KVStore != nil {
+		return NewKVCache[T](config.KVStore, namespace, nil, config.CacheFlags, neededFlags, policy, config.KVCacheOpts...)
+	}
+	return NewIndexedCache[T](config.CacheFlags, neededFlags, policy, indexFunc)
+}
+
+func newGroupedCache[T any](config *Config, namespace string, neededFlags Flags, policy Policy[T]) GroupedCache[T] {
+	if config.KVStore != nil {
+		return NewKVGroupedCache[T](config.KVStore, namespace, nil, config.CacheFlags, neededFlags, policy, config.KVCacheOpts...)
+	}
+	return NewGroupedCache[T](config.CacheFlags, neededFlags, policy)
+}
+
+// WithKVStore makes all entity caches which are not set explicitly store their entities in the given KVStore instead of in memory.
+// Guild readiness & availability as well as the self user are always kept in memory.
+func WithKVStore(store KVStore, opts ...KVCacheConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.KVStore = store
+		config.KVCacheOpts = append(config.KVCacheOpts, opts...)
 	}
 }
 
@@ -124,6 +167,21 @@ func WithCaches(flags ...Flags) ConfigOpt {
 	}
 }
 
+// WithTombstoneTTL sets for how long removed or evicted channels, roles, messages, emojis, stickers and stage instances are kept as tombstones.
+// A ttl of 0 disables tombstones.
+func WithTombstoneTTL(ttl time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.TombstoneTTL = ttl
+	}
+}
+
+// WithTombstoneCache sets the TombstoneCache of the Config.
+func WithTombstoneCache(tombstoneCache TombstoneCache) ConfigOpt {
+	return func(config *Config) {
+		config.TombstoneCache = tombstoneCache
+	}
+}
+
 // WithGuildCachePolicy sets the Policy[discord.Guild] of the Config.
 func WithGuildCachePolicy(policy Policy[discord.Guild]) ConfigOpt {
 	return func(config *Config) {
@@ -1,6 +1,10 @@
 package cache
 
-import "github.com/disgoorg/disgo/internal/flags"
+import (
+	"strings"
+
+	"github.com/disgoorg/disgo/internal/flags"
+)
 
 // Flags are used to enable/disable certain internal caches
 type Flags int
@@ -35,6 +39,38 @@ const (
 		FlagStageInstances
 )
 
+var flagNames = []struct {
+	flag Flags
+	name string
+}{
+	{FlagGuilds, "guilds"},
+	{FlagGuildScheduledEvents, "guild_scheduled_events"},
+	{FlagMembers, "members"},
+	{FlagThreadMembers, "thread_members"},
+	{FlagMessages, "messages"},
+	{FlagPresences, "presences"},
+	{FlagChannels, "channels"},
+	{FlagRoles, "roles"},
+	{FlagEmojis, "emojis"},
+	{FlagStickers, "stickers"},
+	{FlagVoiceStates, "voice_states"},
+	{FlagStageInstances, "stage_instances"},
+}
+
+// String returns the names of all set Flags separated by "|". This is useful to label CacheStats.
+func (f Flags) String() string {
+	var names []string
+	for _, flagName := range flagNames {
+		if f.Has(flagName.flag) {
+			names = append(names, flagName.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
 // Add allows you to add multiple bits together, producing a new bit
 func (f Flags) Add(bits ...Flags) Flags {
 	return flags.Add(f, bits...)
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// CacheStats contains statistics about a single entity cache.
+type CacheStats struct {
+	// Type is the flag of the entity cache these stats belong to.
+	Type Flags
+	// Len is the number of cached entities.
+	Len int
+	// Hits is the number of Get calls which found an entity.
+	Hits uint64
+	// Misses is the number of Get calls which did not find an entity.
+	Misses uint64
+	// PolicyRejections is the number of entities which were not cached because of the configured Policy.
+	PolicyRejections uint64
+	// Evictions is the number of entities which got evicted by a bounded cache.
+	Evictions uint64
+	// ApproxSize is the approximated memory usage of the cached entities in bytes including referenced strings, slices, maps and pointers.
+	// It is extrapolated from a sample of the cached entities and does not account for the overhead of the cache itself.
+	// It is 0 if the cache does not know its size.
+	ApproxSize int
+}
+
+// StatsProvider is implemented by caches which can report CacheStats.
+type StatsProvider interface {
+	Stats() CacheStats
+}
+
+// StatsExporter is called with the stats of all entity caches. It can be used to publish them to your metrics system.
+type StatsExporter func(stats []CacheStats)
+
+// ExportStats calls the given StatsExporter with the stats of the given Caches every interval until the context is done.
+func ExportStats(ctx context.Context, caches Caches, interval time.Duration, exporter StatsExporter) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			exporter(caches.Stats())
+		}
+	}
+}
+
+// cacheCounters contains the counters shared by all cache implementations. It has to be the first field in a struct to guarantee 64-bit alignment on 32-bit platforms.
+type cacheCounters struct {
+	hits             uint64
+	misses           uint64
+	policyRejections uint64
+	evictions        uint64
+}
+
+func (c *cacheCounters) countGet(ok bool) {
+	if ok {
+		atomic.AddUint64(&c.hits, 1)
+	} else {
+		atomic.AddUint64(&c.misses, 1)
+	}
+}
+
+func (c *cacheCounters) countPolicyRejection() {
+	atomic.AddUint64(&c.policyRejections, 1)
+}
+
+func (c *cacheCounters) countEvictions(n int) {
+	atomic.AddUint64(&c.evictions, uint64(n))
+}
+
+func (c *cacheCounters) stats(flags Flags, length int, size int) CacheStats {
+	return CacheStats{
+		Type:             flags,
+		Len:              length,
+		Hits:             atomic.LoadUint64(&c.hits),
+		Misses:           atomic.LoadUint64(&c.misses),
+		PolicyRejections: atomic.LoadUint64(&c.policyRejections),
+		Evictions:        atomic.LoadUint64(&c.evictions),
+		ApproxSize:       size,
+	}
+}
+
+// statsSampleSize is the number of entities which are measured to approximate the memory usage of a cache.
+const statsSampleSize = 100
+
+// approxSize approximates the memory usage of length entities by measuring the given samples.
+// samples should be taken with a sample method, which stops iterating after statsSampleSize entities.
+func approxSize[T any](length int, samples []T) int {
+	if length == 0 || len(samples) == 0 {
+		return 0
+	}
+
+	var size int
+	for i := range samples {
+		size += deepSizeOf(reflect.ValueOf(&samples[i]).Elem())
+	}
+	return size * length / len(samples)
+}
+
+// deepSizeOf returns the size of the given value including all data it references.
+func deepSizeOf(v reflect.Value) int {
+	return int(v.Type().Size()) + referencedSizeOf(v, map[uintptr]struct{}{})
+}
+
+// referencedSizeOf returns the size of the data the given value references. Pointers are only counted once.
+func referencedSizeOf(v reflect.Value, seen map[uintptr]struct{}) int {
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len()
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return 0
+		}
+		if _, ok := seen[v.Pointer()]; ok {
+			return 0
+		}
+		seen[v.Pointer()] = struct{}{}
+		return int(v.Type().Elem().Size()) + referencedSizeOf(v.Elem(), seen)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		// interfaces store their dynamic value behind a pointer unless it is a pointer itself
+		size := referencedSizeOf(elem, seen)
+		if elem.Kind() != reflect.Ptr {
+			size += int(elem.Type().Size())
+		}
+		return size
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		size := v.Cap() * int(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += referencedSizeOf(v.Index(i), seen)
+		}
+		return size
+
+	case reflect.Array:
+		var size int
+		for i := 0; i < v.Len(); i++ {
+			size += referencedSizeOf(v.Index(i), seen)
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		size := v.Len() * int(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			size += referencedSizeOf(iter.Key(), seen) + referencedSizeOf(iter.Value(), seen)
+		}
+		return size
+
+	case reflect.Struct:
+		var size int
+		for i := 0; i < v.NumField(); i++ {
+			size += referencedSizeOf(v.Field(i), seen)
+		}
+		return size
+	}
+	return 0
+}
+
+// statsOf returns the CacheStats of the given cache if it is a StatsProvider or only its length otherwise.
+func statsOf(cache interface{ Len() int }, flags Flags) CacheStats {
+	if provider, ok := cache.(StatsProvider); ok {
+		stats := provider.Stats()
+		stats.Type = flags
+		return stats
+	}
+	return CacheStats{
+		Type: flags,
+		Len:  cache.Len(),
+	}
+}
+
+func (c *cachesImpl) Stats() []CacheStats {
+	var stats []CacheStats
+	for _, cache := range []any{
+		c.GuildCache,
+		c.ChannelCache,
+		c.StageInstanceCache,
+		c.GuildScheduledEventCache,
+		c.RoleCache,
+		c.MemberCache,
+		c.ThreadMemberCache,
+		c.PresenceCache,
+		c.VoiceStateCache,
+		c.MessageCache,
+		c.EmojiCache,
+		c.StickerCache,
+	} {
+		if provider, ok := cache.(StatsProvider); ok {
+			stats = append(stats, provider.Stats())
+		}
+	}
+	return stats
+}
+
+func (c *cachesImpl) GuildStats(guildID snowflake.ID) map[Flags]int {
+	stats := map[Flags]int{}
+	c.GuildChannelsForEach(guildID, func(discord.GuildChannel) { stats[FlagChannels]++ })
+	c.StageInstanceForEach(guildID, func(discord.StageInstance) { stats[FlagStageInstances]++ })
+	c.GuildScheduledEventsForEach(guildID, func(discord.GuildScheduledEvent) { stats[FlagGuildScheduledEvents]++ })
+	c.RolesForEach(guildID, func(discord.Role) { stats[FlagRoles]++ })
+	c.MembersForEach(guildID, func(discord.Member) { stats[FlagMembers]++ })
+	c.PresenceForEach(guildID, func(discord.Presence) { stats[FlagPresences]++ })
+	c.VoiceStatesForEach(guildID, func(discord.VoiceState) { stats[FlagVoiceStates]++ })
+	c.EmojisForEach(guildID, func(discord.Emoji) { stats[FlagEmojis]++ })
+	c.StickersForEach(guildID, func(discord.Sticker) { stats[FlagStickers]++ })
+	return stats
+}
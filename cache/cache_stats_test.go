@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCacheStats(t *testing.T) {
+	c := NewCache[discord.Role](FlagsAll, FlagRoles, func(role discord.Role) bool {
+		return role.Name != "rejected"
+	})
+	c.Put(1, discord.Role{ID: 1, Name: strings.Repeat("a", 1000)})
+	c.Put(2, discord.Role{ID: 2, Name: "rejected"})
+	c.Get(1)
+	c.Get(2)
+
+	stats := c.(StatsProvider).Stats()
+	assert.Equal(t, FlagRoles, stats.Type)
+	assert.Equal(t, 1, stats.Len)
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.PolicyRejections)
+	// the name is referenced data and has to be accounted for
+	assert.Greater(t, stats.ApproxSize, 1000+int(reflect.TypeOf(discord.Role{}).Size())-1)
+}
+
+func TestCacheStats_Sample(t *testing.T) {
+	c := NewGroupedCache[discord.Role](FlagsAll, FlagRoles, nil).(*defaultGroupedCache[discord.Role])
+	for i := 0; i < statsSampleSize*2; i++ {
+		c.Put(snowflake.ID(i%3), snowflake.ID(i), discord.Role{ID: snowflake.ID(i)})
+	}
+	assert.Len(t, c.sample(statsSampleSize), statsSampleSize)
+	assert.Greater(t, c.Stats().ApproxSize, 0)
+}
+
+func TestDeepSizeOf(t *testing.T) {
+	var channel discord.GuildChannel = discord.GuildTextChannel{}
+	// the interface header only is 16 bytes, the dynamic value has to be included
+	assert.Greater(t, deepSizeOf(reflect.ValueOf(&channel).Elem()), int(reflect.TypeOf(discord.GuildTextChannel{}).Size()))
+
+	name := "name"
+	assert.Equal(t, int(reflect.TypeOf(&name).Size())+int(reflect.TypeOf(name).Size())+len(name), deepSizeOf(reflect.ValueOf(&name)))
+}
+
+func TestCaches_StatsExport(t *testing.T) {
+	caches := New(WithCaches(FlagsAll))
+	caches.AddRole(discord.Role{ID: 1, GuildID: 10})
+	caches.AddRole(discord.Role{ID: 2, GuildID: 10})
+	caches.AddEmoji(discord.Emoji{ID: 3, GuildID: 10})
+
+	assert.Equal(t, map[Flags]int{FlagRoles: 2, FlagEmojis: 1}, caches.GuildStats(snowflake.ID(10)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exported := make(chan []CacheStats, 1)
+	go ExportStats(ctx, caches, time.Millisecond, func(stats []CacheStats) {
+		select {
+		case exported <- stats:
+		default:
+		}
+	})
+
+	select {
+	case stats := <-exported:
+		var roles *CacheStats
+		for i := range stats {
+			if stats[i].Type == FlagRoles {
+				roles = &stats[i]
+			}
+		}
+		if assert.NotNil(t, roles) {
+			assert.Equal(t, 2, roles.Len)
+			assert.Greater(t, roles.ApproxSize, 0)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stats were not exported")
+	}
+}
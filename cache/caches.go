@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"io"
 	"sync"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -69,6 +70,10 @@ type guildCacheImpl struct {
 	unavailableGuilds set.Set[snowflake.ID]
 }
 
+func (c *guildCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagGuilds)
+}
+
 func (c *guildCacheImpl) IsGuildUnready(guildID snowflake.ID) bool {
 	return c.unreadyGuilds.Has(guildID)
 }
@@ -94,9 +99,9 @@ func (c *guildCacheImpl) IsGuildUnavailable(guildID snowflake.ID) bool {
 }
 
 func (c *guildCacheImpl) SetGuildUnavailable(guildID snowflake.ID, unavailable bool) {
-	if c.unavailableGuilds.Has(guildID) && unavailable {
+	if c.unavailableGuilds.Has(guildID) && !unavailable {
 		c.unavailableGuilds.Remove(guildID)
-	} else if !c.unavailableGuilds.Has(guildID) && !unavailable {
+	} else if !c.unavailableGuilds.Has(guildID) && unavailable {
 		c.unavailableGuilds.Add(guildID)
 	}
 }
@@ -128,19 +133,31 @@ func (c *guildCacheImpl) RemoveGuild(guildID snowflake.ID) (discord.Guild, bool)
 type ChannelCache interface {
 	Channel(channelID snowflake.ID) (discord.GuildChannel, bool)
 	ChannelsForEach(fn func(channel discord.GuildChannel))
+	GuildChannelsForEach(guildID snowflake.ID, fn func(channel discord.GuildChannel))
 	AddChannel(channel discord.GuildChannel)
 	RemoveChannel(channelID snowflake.ID) (discord.GuildChannel, bool)
 	RemoveChannelsByGuildID(guildID snowflake.ID)
 }
 
+// NewChannelCache returns a new ChannelCache backed by the given Cache.
+// If the Cache is an IndexedCache grouped by guild id, guild scoped operations only touch the channels of that guild.
 func NewChannelCache(cache Cache[discord.GuildChannel]) ChannelCache {
-	return &channelCacheImpl{
+	c := &channelCacheImpl{
 		cache: cache,
 	}
+	if indexedCache, ok := cache.(IndexedCache[discord.GuildChannel]); ok {
+		c.indexedCache = indexedCache
+	}
+	return c
 }
 
 type channelCacheImpl struct {
-	cache Cache[discord.GuildChannel]
+	cache        Cache[discord.GuildChannel]
+	indexedCache IndexedCache[discord.GuildChannel]
+}
+
+func (c *channelCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagChannels)
 }
 
 func (c *channelCacheImpl) Channel(channelID snowflake.ID) (discord.GuildChannel, bool) {
@@ -151,6 +168,18 @@ func (c *channelCacheImpl) ChannelsForEach(fn func(channel discord.GuildChannel)
 	c.cache.ForEach(fn)
 }
 
+func (c *channelCacheImpl) GuildChannelsForEach(guildID snowflake.ID, fn func(channel discord.GuildChannel)) {
+	if c.indexedCache != nil {
+		c.indexedCache.GroupForEach(guildID, fn)
+		return
+	}
+	c.cache.ForEach(func(channel discord.GuildChannel) {
+		if channel.GuildID() == guildID {
+			fn(channel)
+		}
+	})
+}
+
 func (c *channelCacheImpl) AddChannel(channel discord.GuildChannel) {
 	c.cache.Put(channel.ID(), channel)
 }
@@ -160,6 +189,10 @@ func (c *channelCacheImpl) RemoveChannel(channelID snowflake.ID) (discord.GuildC
 }
 
 func (c *channelCacheImpl) RemoveChannelsByGuildID(guildID snowflake.ID) {
+	if c.indexedCache != nil {
+		c.indexedCache.GroupRemove(guildID)
+		return
+	}
 	c.cache.RemoveIf(func(channel discord.GuildChannel) bool {
 		return channel.GuildID() == guildID
 	})
@@ -183,6 +216,10 @@ type stageInstanceCacheImpl struct {
 	cache GroupedCache[discord.StageInstance]
 }
 
+func (c *stageInstanceCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagStageInstances)
+}
+
 func (c *stageInstanceCacheImpl) StageInstance(guildID snowflake.ID, stageInstanceID snowflake.ID) (discord.StageInstance, bool) {
 	return c.cache.Get(guildID, stageInstanceID)
 }
@@ -223,6 +260,10 @@ type guildScheduledEventCacheImpl struct {
 	cache GroupedCache[discord.GuildScheduledEvent]
 }
 
+func (c *guildScheduledEventCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagGuildScheduledEvents)
+}
+
 func (c *guildScheduledEventCacheImpl) GuildScheduledEvent(guildID snowflake.ID, guildScheduledEventID snowflake.ID) (discord.GuildScheduledEvent, bool) {
 	return c.cache.Get(guildID, guildScheduledEventID)
 }
@@ -261,6 +302,10 @@ type roleCacheImpl struct {
 	cache GroupedCache[discord.Role]
 }
 
+func (c *roleCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagRoles)
+}
+
 func (c *roleCacheImpl) Role(guildID snowflake.ID, roleID snowflake.ID) (discord.Role, bool) {
 	return c.cache.Get(guildID, roleID)
 }
@@ -299,6 +344,10 @@ type memberCacheImpl struct {
 	cache GroupedCache[discord.Member]
 }
 
+func (c *memberCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagMembers)
+}
+
 func (c *memberCacheImpl) Member(guildID snowflake.ID, userID snowflake.ID) (discord.Member, bool) {
 	return c.cache.Get(guildID, userID)
 }
@@ -337,6 +386,10 @@ type threadMemberCacheImpl struct {
 	cache GroupedCache[discord.ThreadMember]
 }
 
+func (c *threadMemberCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagThreadMembers)
+}
+
 func (c *threadMemberCacheImpl) ThreadMember(threadID snowflake.ID, userID snowflake.ID) (discord.ThreadMember, bool) {
 	return c.cache.Get(threadID, userID)
 }
@@ -377,6 +430,10 @@ type presenceCacheImpl struct {
 	cache GroupedCache[discord.Presence]
 }
 
+func (c *presenceCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagPresences)
+}
+
 func (c *presenceCacheImpl) Presence(guildID snowflake.ID, userID snowflake.ID) (discord.Presence, bool) {
 	return c.cache.Get(guildID, userID)
 }
@@ -417,6 +474,10 @@ type voiceStateCacheImpl struct {
 	cache GroupedCache[discord.VoiceState]
 }
 
+func (c *voiceStateCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagVoiceStates)
+}
+
 func (c *voiceStateCacheImpl) VoiceState(guildID snowflake.ID, userID snowflake.ID) (discord.VoiceState, bool) {
 	return c.cache.Get(guildID, userID)
 }
@@ -440,20 +501,48 @@ func (c *voiceStateCacheImpl) RemoveVoiceStatesByGuildID(guildID snowflake.ID) {
 type MessageCache interface {
 	Message(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool)
 	MessagesForEach(channelID snowflake.ID, fn func(message discord.Message))
+	GuildMessagesForEach(guildID snowflake.ID, fn func(message discord.Message))
 	AddMessage(message discord.Message)
 	RemoveMessage(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool)
 	RemoveMessagesByChannelID(channelID snowflake.ID)
 	RemoveMessagesByGuildID(guildID snowflake.ID)
 }
 
+// NewMessageCache returns a new MessageCache backed by the given GroupedCache.
+// If the GroupedCache is process-local, the channels with cached messages are indexed per guild, so guild scoped operations only touch those channels.
 func NewMessageCache(cache GroupedCache[discord.Message]) MessageCache {
-	return &messageCacheImpl{
-		cache: cache,
+	c := &messageCacheImpl{
+		cache:         cache,
+		guildChannels: make(map[snowflake.ID]map[snowflake.ID]struct{}),
+		channelGuilds: make(map[snowflake.ID]snowflake.ID),
+	}
+	switch cache.(type) {
+	case *defaultGroupedCache[discord.Message], *boundedGroupedCache[discord.Message]:
+		c.indexed = true
 	}
+	if notifier, ok := cache.(EvictNotifier[discord.Message]); ok {
+		notifier.AddEvictFunc(func(channelID snowflake.ID, _ snowflake.ID, _ discord.Message, _ EvictReason) {
+			c.pruneChannel(channelID)
+		})
+	}
+	return c
 }
 
+var _ EvictNotifier[discord.Message] = (*messageCacheImpl)(nil)
+
 type messageCacheImpl struct {
 	cache GroupedCache[discord.Message]
+
+	// guild id -> channel ids with cached messages, used to remove messages by guild id without iterating over all messages.
+	// Only maintained if indexed is set, as other processes might add messages to a shared cache without updating it.
+	indexed         bool
+	guildChannels   map[snowflake.ID]map[snowflake.ID]struct{}
+	channelGuilds   map[snowflake.ID]snowflake.ID
+	guildChannelsMu sync.Mutex
+}
+
+func (c *messageCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagMessages)
 }
 
 func (c *messageCacheImpl) Message(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
@@ -464,22 +553,107 @@ func (c *messageCacheImpl) MessagesForEach(channelID snowflake.ID, fn func(messa
 	c.cache.GroupForEach(channelID, fn)
 }
 
+func (c *messageCacheImpl) GuildMessagesForEach(guildID snowflake.ID, fn func(message discord.Message)) {
+	if !c.indexed {
+		c.cache.ForEach(func(_ snowflake.ID, message discord.Message) {
+			if message.GuildID != nil && *message.GuildID == guildID {
+				fn(message)
+			}
+		})
+		return
+	}
+
+	c.guildChannelsMu.Lock()
+	channelIDs := make([]snowflake.ID, 0, len(c.guildChannels[guildID]))
+	for channelID := range c.guildChannels[guildID] {
+		channelIDs = append(channelIDs, channelID)
+	}
+	c.guildChannelsMu.Unlock()
+
+	for _, channelID := range channelIDs {
+		c.cache.GroupForEach(channelID, fn)
+	}
+}
+
+// AddEvictFunc adds the EvictFunc to the underlying GroupedCache if it evicts messages on its own.
+func (c *messageCacheImpl) AddEvictFunc(evictFunc EvictFunc[discord.Message]) {
+	if notifier, ok := c.cache.(EvictNotifier[discord.Message]); ok {
+		notifier.AddEvictFunc(evictFunc)
+	}
+}
+
 func (c *messageCacheImpl) AddMessage(message discord.Message) {
 	c.cache.Put(message.ChannelID, message.ID, message)
+	// only index the channel if the message passed the flags & policy
+	if !c.indexed || message.GuildID == nil || c.cache.GroupLen(message.ChannelID) == 0 {
+		return
+	}
+	c.guildChannelsMu.Lock()
+	defer c.guildChannelsMu.Unlock()
+	channelIDs, ok := c.guildChannels[*message.GuildID]
+	if !ok {
+		channelIDs = make(map[snowflake.ID]struct{})
+		c.guildChannels[*message.GuildID] = channelIDs
+	}
+	channelIDs[message.ChannelID] = struct{}{}
+	c.channelGuilds[message.ChannelID] = *message.GuildID
 }
 
 func (c *messageCacheImpl) RemoveMessage(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
-	return c.cache.Remove(channelID, messageID)
+	message, ok := c.cache.Remove(channelID, messageID)
+	c.pruneChannel(channelID)
+	return message, ok
 }
 
 func (c *messageCacheImpl) RemoveMessagesByChannelID(channelID snowflake.ID) {
+	c.guildChannelsMu.Lock()
+	c.unindexChannel(channelID)
+	c.guildChannelsMu.Unlock()
 	c.cache.GroupRemove(channelID)
 }
 
+// pruneChannel removes the channel from the guild index if it has no cached messages anymore.
+// GroupLen is called without holding guildChannelsMu, as it might evict messages which calls pruneChannel again.
+func (c *messageCacheImpl) pruneChannel(channelID snowflake.ID) {
+	if !c.indexed || c.cache.GroupLen(channelID) > 0 {
+		return
+	}
+	c.guildChannelsMu.Lock()
+	defer c.guildChannelsMu.Unlock()
+	c.unindexChannel(channelID)
+}
+
+func (c *messageCacheImpl) unindexChannel(channelID snowflake.ID) {
+	guildID, ok := c.channelGuilds[channelID]
+	if !ok {
+		return
+	}
+	delete(c.channelGuilds, channelID)
+	delete(c.guildChannels[guildID], channelID)
+	if len(c.guildChannels[guildID]) == 0 {
+		delete(c.guildChannels, guildID)
+	}
+}
+
 func (c *messageCacheImpl) RemoveMessagesByGuildID(guildID snowflake.ID) {
-	c.cache.RemoveIf(func(_ snowflake.ID, message discord.Message) bool {
-		return message.GuildID != nil && *message.GuildID == guildID
-	})
+	if !c.indexed {
+		c.cache.RemoveIf(func(_ snowflake.ID, message discord.Message) bool {
+			return message.GuildID != nil && *message.GuildID == guildID
+		})
+		return
+	}
+
+	c.guildChannelsMu.Lock()
+	channelIDs := c.guildChannels[guildID]
+	delete(c.guildChannels, guildID)
+	for channelID := range channelIDs {
+		delete(c.channelGuilds, channelID)
+	}
+	c.guildChannelsMu.Unlock()
+
+	for channelID := range channelIDs {
+		c.cache.GroupRemove(channelID)
+	}
 }
 
 type EmojiCache interface {
@@ -500,6 +674,10 @@ type emojiCacheImpl struct {
 	cache GroupedCache[discord.Emoji]
 }
 
+func (c *emojiCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagEmojis)
+}
+
 func (c *emojiCacheImpl) Emoji(guildID snowflake.ID, emojiID snowflake.ID) (discord.Emoji, bool) {
 	return c.cache.Get(guildID, emojiID)
 }
@@ -538,6 +716,10 @@ type stickerCacheImpl struct {
 	cache GroupedCache[discord.Sticker]
 }
 
+func (c *stickerCacheImpl) Stats() CacheStats {
+	return statsOf(c.cache, FlagStickers)
+}
+
 func (c *stickerCacheImpl) Sticker(guildID snowflake.ID, stickerID snowflake.ID) (discord.Sticker, bool) {
 	return c.cache.Get(guildID, stickerID)
 }
@@ -576,10 +758,24 @@ type Caches interface {
 	MessageCache
 	EmojiCache
 	StickerCache
+	TombstoneCache
 
 	// CacheFlags returns the current configured FLags of the caches.
 	CacheFlags() Flags
 
+	// Stats returns the CacheStats of all entity caches which implement StatsProvider.
+	Stats() []CacheStats
+
+	// GuildStats returns the number of cached entities per cache type in the given guild.
+	GuildStats(guildID snowflake.ID) map[Flags]int
+
+	// Snapshot writes all cached guilds, channels, roles, members, emojis, voice states and the guild state to the given io.Writer.
+	// It is only consistent if the caches are not modified while it is taken, for example after closing the gateway with websocket.CloseServiceRestart.
+	Snapshot(w io.Writer) error
+
+	// Restore reads a snapshot written by Snapshot from the given io.Reader and adds all entities to the caches.
+	Restore(r io.Reader) error
+
 	// MemberPermissions returns the calculated permissions of the given member.
 	// This requires the FlagRoles to be set.
 	MemberPermissions(member discord.Member) discord.Permissions
@@ -645,7 +841,7 @@ func New(opts ...ConfigOpt) Caches {
 	config := DefaultConfig()
 	config.Apply(opts)
 
-	return &cachesImpl{
+	c := &cachesImpl{
 		config:                   *config,
 		SelfUserCache:            config.SelfUserCache,
 		GuildCache:               config.GuildCache,
@@ -660,7 +856,17 @@ func New(opts ...ConfigOpt) Caches {
 		MessageCache:             config.MessageCache,
 		EmojiCache:               config.EmojiCache,
 		StickerCache:             config.StickerCache,
+		TombstoneCache:           config.TombstoneCache,
+	}
+	// the default TombstoneCache with a ttl of 0 discards all tombstones, so we don't need to collect them
+	_, defaultTombstones := config.TombstoneCache.(*tombstoneCacheImpl)
+	c.tombstones = !defaultTombstones || config.TombstoneTTL > 0
+	if notifier, ok := config.MessageCache.(EvictNotifier[discord.Message]); ok && c.tombstones {
+		notifier.AddEvictFunc(func(_ snowflake.ID, _ snowflake.ID, message discord.Message, _ EvictReason) {
+			c.AddDeletedMessage(message)
+		})
 	}
+	return c
 }
 
 type cachesImpl struct {
@@ -679,12 +885,92 @@ type cachesImpl struct {
 	EmojiCache
 	StickerCache
 	SelfUserCache
+	TombstoneCache
+
+	// whether removed entities should be collected for the TombstoneCache
+	tombstones bool
 }
 
 func (c *cachesImpl) CacheFlags() Flags {
 	return c.config.CacheFlags
 }
 
+func (c *cachesImpl) RemoveChannel(channelID snowflake.ID) (discord.GuildChannel, bool) {
+	channel, ok := c.ChannelCache.RemoveChannel(channelID)
+	if ok {
+		c.AddDeletedChannel(channel)
+	}
+	return channel, ok
+}
+
+func (c *cachesImpl) RemoveRole(guildID snowflake.ID, roleID snowflake.ID) (discord.Role, bool) {
+	role, ok := c.RoleCache.RemoveRole(guildID, roleID)
+	if ok {
+		c.AddDeletedRole(role)
+	}
+	return role, ok
+}
+
+func (c *cachesImpl) RemoveMessage(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
+	message, ok := c.MessageCache.RemoveMessage(channelID, messageID)
+	if ok {
+		c.AddDeletedMessage(message)
+	}
+	return message, ok
+}
+
+func (c *cachesImpl) RemoveChannelsByGuildID(guildID snowflake.ID) {
+	var channels []discord.GuildChannel
+	if c.tombstones {
+		c.GuildChannelsForEach(guildID, func(channel discord.GuildChannel) {
+			channels = append(channels, channel)
+		})
+	}
+	c.ChannelCache.RemoveChannelsByGuildID(guildID)
+	for _, channel := range channels {
+		c.AddDeletedChannel(channel)
+	}
+}
+
+func (c *cachesImpl) RemoveRolesByGuildID(guildID snowflake.ID) {
+	var roles []discord.Role
+	if c.tombstones {
+		c.RolesForEach(guildID, func(role discord.Role) {
+			roles = append(roles, role)
+		})
+	}
+	c.RoleCache.RemoveRolesByGuildID(guildID)
+	for _, role := range roles {
+		c.AddDeletedRole(role)
+	}
+}
+
+func (c *cachesImpl) RemoveMessagesByChannelID(channelID snowflake.ID) {
+	var messages []discord.Message
+	if c.tombstones {
+		c.MessagesForEach(channelID, func(message discord.Message) {
+			messages = append(messages, message)
+		})
+	}
+	c.MessageCache.RemoveMessagesByChannelID(channelID)
+	for _, message := range messages {
+		c.AddDeletedMessage(message)
+	}
+}
+
+func (c *cachesImpl) RemoveMessagesByGuildID(guildID snowflake.ID) {
+	var messages []discord.Message
+	if c.tombstones {
+		c.GuildMessagesForEach(guildID, func(message discord.Message) {
+			messages = append(messages, message)
+		})
+	}
+	c.MessageCache.RemoveMessagesByGuildID(guildID)
+	for _, message := range messages {
+		c.AddDeletedMessage(message)
+	}
+}
+
 func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
 	if guild, ok := c.Guild(member.GuildID); ok && guild.OwnerID == member.User.ID {
 		return discord.PermissionsAll
@@ -786,11 +1072,16 @@ func (c *cachesImpl) SelfMember(guildID snowflake.ID) (discord.Member, bool) {
 
 func (c *cachesImpl) GuildThreadsInChannel(channelID snowflake.ID) []discord.GuildThread {
 	var threads []discord.GuildThread
-	c.ChannelsForEach(func(channel discord.GuildChannel) {
+	fn := func(channel discord.GuildChannel) {
 		if thread, ok := channel.(discord.GuildThread); ok && *thread.ParentID() == channelID {
 			threads = append(threads, thread)
 		}
-	})
+	}
+	if channel, ok := c.Channel(channelID); ok {
+		c.GuildChannelsForEach(channel.GuildID(), fn)
+	} else {
+		c.ChannelsForEach(fn)
+	}
 	return threads
 }
 
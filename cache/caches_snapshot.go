@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// SnapshotVersion is the version of the format written by Caches.Snapshot. Restoring a snapshot with a different version fails.
+const SnapshotVersion = 1
+
+// Snapshot is the serialized form of Caches written by Caches.Snapshot.
+type Snapshot struct {
+	Version     int                        `json:"version"`
+	SelfUser    *discord.OAuth2User        `json:"self_user,omitempty"`
+	Guilds      []discord.Guild            `json:"guilds"`
+	Channels    []discord.UnmarshalChannel `json:"channels"`
+	Roles       []discord.Role             `json:"roles"`
+	Members     []discord.Member           `json:"members"`
+	Emojis      []discord.Emoji            `json:"emojis"`
+	VoiceStates []discord.VoiceState       `json:"voice_states"`
+
+	UnreadyGuildIDs     []snowflake.ID `json:"unready_guild_ids,omitempty"`
+	UnavailableGuildIDs []snowflake.ID `json:"unavailable_guild_ids,omitempty"`
+}
+
+// Snapshot writes all cached guilds, channels, roles, members, emojis, voice states and the guild state to the given io.Writer.
+// See Caches.Snapshot for when the snapshot is consistent.
+func (c *cachesImpl) Snapshot(w io.Writer) error {
+	snapshot := Snapshot{
+		Version:             SnapshotVersion,
+		UnreadyGuildIDs:     c.UnreadyGuildIDs(),
+		UnavailableGuildIDs: c.UnavailableGuildIDs(),
+	}
+	if selfUser, ok := c.SelfUser(); ok {
+		snapshot.SelfUser = &selfUser
+	}
+
+	c.GuildsForEach(func(guild discord.Guild) {
+		snapshot.Guilds = append(snapshot.Guilds, guild)
+
+		c.RolesForEach(guild.ID, func(role discord.Role) {
+			snapshot.Roles = append(snapshot.Roles, role)
+		})
+		c.MembersForEach(guild.ID, func(member discord.Member) {
+			snapshot.Members = append(snapshot.Members, member)
+		})
+		c.EmojisForEach(guild.ID, func(emoji discord.Emoji) {
+			snapshot.Emojis = append(snapshot.Emojis, emoji)
+		})
+		c.VoiceStatesForEach(guild.ID, func(voiceState discord.VoiceState) {
+			snapshot.VoiceStates = append(snapshot.VoiceStates, voiceState)
+		})
+	})
+	c.ChannelsForEach(func(channel discord.GuildChannel) {
+		snapshot.Channels = append(snapshot.Channels, discord.UnmarshalChannel{Channel: channel})
+	})
+
+	return json.NewEncoder(w).Encode(snapshot)
+}
+
+// Restore reads a snapshot written by Snapshot from the given io.Reader and adds all entities to the caches.
+// Existing entities are overwritten but not removed. Entities are still subject to the configured Flags and Policy(s).
+func (c *cachesImpl) Restore(r io.Reader) error {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return err
+	}
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, SnapshotVersion)
+	}
+
+	// validate the whole snapshot first, so a malformed one doesn't leave the caches partially restored
+	guildChannels := make([]discord.GuildChannel, 0, len(snapshot.Channels))
+	for i, channel := range snapshot.Channels {
+		if channel.Channel == nil || channel.ID() == 0 {
+			return fmt.Errorf("channel %d in snapshot is empty", i)
+		}
+		guildChannel, ok := channel.Channel.(discord.GuildChannel)
+		if !ok {
+			return fmt.Errorf("channel %s in snapshot is not a guild channel", channel.ID())
+		}
+		guildChannels = append(guildChannels, guildChannel)
+	}
+
+	if snapshot.SelfUser != nil {
+		c.SetSelfUser(*snapshot.SelfUser)
+	}
+	for _, guild := range snapshot.Guilds {
+		c.AddGuild(guild)
+	}
+	for _, guildID := range snapshot.UnreadyGuildIDs {
+		c.SetGuildUnready(guildID, true)
+	}
+	for _, guildID := range snapshot.UnavailableGuildIDs {
+		c.SetGuildUnavailable(guildID, true)
+	}
+	for _, guildChannel := range guildChannels {
+		c.AddChannel(guildChannel)
+	}
+	for _, role := range snapshot.Roles {
+		c.AddRole(role)
+	}
+	for _, member := range snapshot.Members {
+		c.AddMember(member)
+	}
+	for _, emoji := range snapshot.Emojis {
+		c.AddEmoji(emoji)
+	}
+	for _, voiceState := range snapshot.VoiceStates {
+		c.AddVoiceState(voiceState)
+	}
+	return nil
+}
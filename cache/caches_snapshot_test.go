@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCaches_SnapshotRestore(t *testing.T) {
+	guildID := snowflake.ID(1)
+	var channel discord.GuildTextChannel
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"2","type":0,"guild_id":"1","name":"general"}`), &channel))
+	userID := snowflake.ID(3)
+	channelID := channel.ID()
+
+	caches := New(WithCaches(FlagsAll))
+	caches.SetSelfUser(discord.OAuth2User{User: discord.User{ID: userID, Username: "bot"}})
+	caches.AddGuild(discord.Guild{ID: guildID, Name: "guild"})
+	caches.AddChannel(channel)
+	caches.AddRole(discord.Role{ID: 4, GuildID: guildID, Name: "role"})
+	caches.AddMember(discord.Member{GuildID: guildID, User: discord.User{ID: userID, Username: "bot"}})
+	caches.AddEmoji(discord.Emoji{ID: 5, GuildID: guildID, Name: "emoji"})
+	caches.AddVoiceState(discord.VoiceState{GuildID: guildID, UserID: userID, ChannelID: &channelID})
+	caches.SetGuildUnready(6, true)
+	caches.SetGuildUnavailable(7, true)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, caches.Snapshot(buf))
+
+	restored := New(WithCaches(FlagsAll))
+	assert.NoError(t, restored.Restore(buf))
+
+	selfUser, ok := restored.SelfUser()
+	assert.True(t, ok)
+	assert.Equal(t, userID, selfUser.ID)
+
+	guild, ok := restored.Guild(guildID)
+	assert.True(t, ok)
+	assert.Equal(t, "guild", guild.Name)
+
+	restoredChannel, ok := restored.Channel(channel.ID())
+	assert.True(t, ok)
+	assert.Equal(t, channel, restoredChannel)
+
+	role, ok := restored.Role(guildID, 4)
+	assert.True(t, ok)
+	assert.Equal(t, "role", role.Name)
+
+	member, ok := restored.Member(guildID, userID)
+	assert.True(t, ok)
+	assert.Equal(t, "bot", member.User.Username)
+
+	emoji, ok := restored.Emoji(guildID, 5)
+	assert.True(t, ok)
+	assert.Equal(t, "emoji", emoji.Name)
+
+	voiceState, ok := restored.VoiceState(guildID, userID)
+	assert.True(t, ok)
+	assert.Equal(t, &channelID, voiceState.ChannelID)
+
+	assert.True(t, restored.IsGuildUnready(6))
+	assert.True(t, restored.IsGuildUnavailable(7))
+
+	assert.Error(t, restored.Restore(bytes.NewBufferString(`{"version":0}`)))
+}
+
+func TestCaches_RestoreMalformed(t *testing.T) {
+	caches := New(WithCaches(FlagsAll))
+	assert.Error(t, caches.Restore(bytes.NewBufferString(`{"version":1,"guilds":[{"id":"1","name":"guild"}],"channels":[null]}`)))
+	assert.Error(t, caches.Restore(bytes.NewBufferString(`{"version":1,"guilds":[{"id":"1","name":"guild"}],"channels":[{"id":"2","type":1}]}`)))
+
+	_, ok := caches.Guild(1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Codec is used to serialize entities before they are written to a KVStore and to deserialize them again.
+type Codec[T any] interface {
+	// Encode serializes the given entity.
+	Encode(entity T) ([]byte, error)
+
+	// Decode deserializes the given data into an entity.
+	Decode(data []byte) (T, error)
+}
+
+// DefaultCodec returns the Codec which fits the given type best.
+// For discord.GuildChannel this is GuildChannelCodec, for everything else JSONCodec.
+func DefaultCodec[T any]() Codec[T] {
+	if codec, ok := any(GuildChannelCodec{}).(Codec[T]); ok {
+		return codec
+	}
+	return JSONCodec[T]{}
+}
+
+var _ Codec[any] = (*JSONCodec[any])(nil)
+
+// JSONCodec is a Codec which uses json to serialize entities. It does not work with interface types, use a specialized Codec like GuildChannelCodec for them.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(entity T) ([]byte, error) {
+	return json.Marshal(entity)
+}
+
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var entity T
+	err := json.Unmarshal(data, &entity)
+	return entity, err
+}
+
+var _ Codec[discord.GuildChannel] = (*GuildChannelCodec)(nil)
+
+// GuildChannelCodec is a Codec for the polymorphic discord.GuildChannel.
+// The concrete channel type is restored from the type field via discord.UnmarshalChannel.
+type GuildChannelCodec struct{}
+
+func (GuildChannelCodec) Encode(channel discord.GuildChannel) ([]byte, error) {
+	return json.Marshal(channel)
+}
+
+func (GuildChannelCodec) Decode(data []byte) (discord.GuildChannel, error) {
+	var v discord.UnmarshalChannel
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	channel, ok := v.Channel.(discord.GuildChannel)
+	if !ok {
+		return nil, fmt.Errorf("channel with type %d is not a guild channel", v.Channel.Type())
+	}
+	return channel, nil
+}
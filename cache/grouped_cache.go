@@ -43,7 +43,10 @@ type GroupedCache[T any] interface {
 	GroupForEach(groupID snowflake.ID, forEachFunc func(entity T))
 }
 
-var _ GroupedCache[any] = (*defaultGroupedCache[any])(nil)
+var (
+	_ GroupedCache[any] = (*defaultGroupedCache[any])(nil)
+	_ StatsProvider     = (*defaultGroupedCache[any])(nil)
+)
 
 // NewGroupedCache returns a new default GroupedCache with the provided flags, neededFlags and policy.
 func NewGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) GroupedCache[T] {
@@ -56,6 +59,7 @@ func NewGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) Gr
 }
 
 type defaultGroupedCache[T any] struct {
+	cacheCounters
 	mu          sync.RWMutex
 	flags       Flags
 	neededFlags Flags
@@ -69,10 +73,12 @@ func (c *defaultGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T,
 
 	if groupEntities, ok := c.cache[groupID]; ok {
 		if entity, ok := groupEntities[id]; ok {
+			c.countGet(true)
 			return entity, true
 		}
 	}
 
+	c.countGet(false)
 	var entity T
 	return entity, false
 }
@@ -82,6 +88,7 @@ func (c *defaultGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, enti
 		return
 	}
 	if c.policy != nil && !c.policy(entity) {
+		c.countPolicyRejection()
 		return
 	}
 	c.mu.Lock()
@@ -184,3 +191,25 @@ func (c *defaultGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc
 		forEachFunc(entity)
 	}
 }
+
+func (c *defaultGroupedCache[T]) Stats() CacheStats {
+	length := c.Len()
+	return c.stats(c.neededFlags, length, approxSize(length, c.sample(statsSampleSize)))
+}
+
+// sample returns up to n entities of the cache.
+func (c *defaultGroupedCache[T]) sample(n int) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	samples := make([]T, 0, n)
+	for _, groupEntities := range c.cache {
+		for _, entity := range groupEntities {
+			if len(samples) == n {
+				return samples
+			}
+			samples = append(samples, entity)
+		}
+	}
+	return samples
+}
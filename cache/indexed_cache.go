@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// IndexFunc returns the snowflake.ID of the group the entity belongs to.
+type IndexFunc[T any] func(entity T) snowflake.ID
+
+// IndexedCache is a Cache which additionally keeps an index of its entities by a group snowflake.ID.
+// This allows group scoped lookups, iteration and removal without iterating over the whole cache.
+type IndexedCache[T any] interface {
+	Cache[T]
+
+	// GroupRemove removes all entities in the given groupID.
+	GroupRemove(groupID snowflake.ID)
+
+	// GroupLen returns the number of entities in the cache within the groupID.
+	GroupLen(groupID snowflake.ID) int
+
+	// GroupForEach calls the given function for each entity in the cache within the groupID.
+	GroupForEach(groupID snowflake.ID, forEachFunc func(entity T))
+}
+
+var (
+	_ IndexedCache[any] = (*defaultIndexedCache[any])(nil)
+	_ StatsProvider     = (*defaultIndexedCache[any])(nil)
+)
+
+// NewIndexedCache returns a new thread safe IndexedCache which groups its entities by the given IndexFunc and filters them after the given Flags and Policy.
+func NewIndexedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], indexFunc IndexFunc[T]) IndexedCache[T] {
+	return &defaultIndexedCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		indexFunc:   indexFunc,
+		cache:       make(map[snowflake.ID]T),
+		index:       make(map[snowflake.ID]map[snowflake.ID]struct{}),
+	}
+}
+
+type defaultIndexedCache[T any] struct {
+	cacheCounters
+	mu          sync.RWMutex
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	indexFunc   IndexFunc[T]
+	cache       map[snowflake.ID]T
+	// group id -> entity ids
+	index map[snowflake.ID]map[snowflake.ID]struct{}
+}
+
+func (c *defaultIndexedCache[T]) addIndex(id snowflake.ID, entity T) {
+	groupID := c.indexFunc(entity)
+	ids, ok := c.index[groupID]
+	if !ok {
+		ids = make(map[snowflake.ID]struct{})
+		c.index[groupID] = ids
+	}
+	ids[id] = struct{}{}
+}
+
+func (c *defaultIndexedCache[T]) removeIndex(id snowflake.ID, entity T) {
+	groupID := c.indexFunc(entity)
+	if ids, ok := c.index[groupID]; ok {
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(c.index, groupID)
+		}
+	}
+}
+
+func (c *defaultIndexedCache[T]) Get(id snowflake.ID) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entity, ok := c.cache[id]
+	c.countGet(ok)
+	return entity, ok
+}
+
+func (c *defaultIndexedCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		c.countPolicyRejection()
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if oldEntity, ok := c.cache[id]; ok {
+		c.removeIndex(id, oldEntity)
+	}
+	c.cache[id] = entity
+	c.addIndex(id, entity)
+}
+
+func (c *defaultIndexedCache[T]) Remove(id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entity, ok := c.cache[id]
+	if ok {
+		delete(c.cache, id)
+		c.removeIndex(id, entity)
+	}
+	return entity, ok
+}
+
+func (c *defaultIndexedCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, entity := range c.cache {
+		if filterFunc(entity) {
+			delete(c.cache, id)
+			c.removeIndex(id, entity)
+		}
+	}
+}
+
+func (c *defaultIndexedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range c.index[groupID] {
+		delete(c.cache, id)
+	}
+	delete(c.index, groupID)
+}
+
+func (c *defaultIndexedCache[T]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.cache)
+}
+
+func (c *defaultIndexedCache[T]) GroupLen(groupID snowflake.ID) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.index[groupID])
+}
+
+func (c *defaultIndexedCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, entity := range c.cache {
+		forEachFunc(entity)
+	}
+}
+
+func (c *defaultIndexedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for id := range c.index[groupID] {
+		forEachFunc(c.cache[id])
+	}
+}
+
+func (c *defaultIndexedCache[T]) Stats() CacheStats {
+	length := c.Len()
+	return c.stats(c.neededFlags, length, approxSize(length, c.sample(statsSampleSize)))
+}
+
+// sample returns up to n entities of the cache.
+func (c *defaultIndexedCache[T]) sample(n int) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	samples := make([]T, 0, n)
+	for _, entity := range c.cache {
+		if len(samples) == n {
+			break
+		}
+		samples = append(samples, entity)
+	}
+	return samples
+}
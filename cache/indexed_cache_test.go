@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type testIndexedEntity struct {
+	id      snowflake.ID
+	groupID snowflake.ID
+}
+
+func TestIndexedCache(t *testing.T) {
+	c := NewIndexedCache[testIndexedEntity](FlagsAll, FlagChannels, nil, func(entity testIndexedEntity) snowflake.ID {
+		return entity.groupID
+	})
+
+	c.Put(1, testIndexedEntity{id: 1, groupID: 10})
+	c.Put(2, testIndexedEntity{id: 2, groupID: 10})
+	c.Put(3, testIndexedEntity{id: 3, groupID: 20})
+	assert.Equal(t, 2, c.GroupLen(10))
+	assert.Equal(t, 1, c.GroupLen(20))
+
+	// moving an entity to another group updates the index
+	c.Put(2, testIndexedEntity{id: 2, groupID: 20})
+	assert.Equal(t, 1, c.GroupLen(10))
+	assert.Equal(t, 2, c.GroupLen(20))
+
+	var ids []snowflake.ID
+	c.GroupForEach(20, func(entity testIndexedEntity) {
+		ids = append(ids, entity.id)
+	})
+	assert.ElementsMatch(t, []snowflake.ID{2, 3}, ids)
+
+	c.Remove(1)
+	assert.Equal(t, 0, c.GroupLen(10))
+
+	c.GroupRemove(20)
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, c.GroupLen(20))
+}
+
+func newTestMessage(guildID snowflake.ID, channelID snowflake.ID, id snowflake.ID) discord.Message {
+	return discord.Message{ID: id, ChannelID: channelID, GuildID: &guildID}
+}
+
+func TestMessageCache_GuildIndex(t *testing.T) {
+	messageCache := NewMessageCache(NewBoundedGroupedCache[discord.Message](FlagsAll, FlagMessages, nil, nil, WithMaxGroupSize(1))).(*messageCacheImpl)
+
+	messageCache.AddMessage(newTestMessage(1, 10, 100))
+	messageCache.AddMessage(newTestMessage(1, 11, 110))
+	messageCache.AddMessage(newTestMessage(2, 20, 200))
+	assert.Len(t, messageCache.guildChannels[1], 2)
+
+	var ids []snowflake.ID
+	messageCache.GuildMessagesForEach(1, func(message discord.Message) {
+		ids = append(ids, message.ID)
+	})
+	assert.ElementsMatch(t, []snowflake.ID{100, 110}, ids)
+
+	// evicting a message keeps the channel indexed as long as it has messages
+	messageCache.AddMessage(newTestMessage(1, 10, 101))
+	assert.Len(t, messageCache.guildChannels[1], 2)
+
+	messageCache.RemoveMessage(11, 110)
+	assert.Len(t, messageCache.guildChannels[1], 1)
+	assert.NotContains(t, messageCache.channelGuilds, snowflake.ID(11))
+
+	messageCache.RemoveMessagesByGuildID(1)
+	assert.NotContains(t, messageCache.guildChannels, snowflake.ID(1))
+	_, ok := messageCache.Message(10, 101)
+	assert.False(t, ok)
+
+	messageCache.RemoveMessagesByChannelID(20)
+	assert.Empty(t, messageCache.guildChannels)
+	assert.Empty(t, messageCache.channelGuilds)
+}
+
+func TestMessageCache_GuildIndexRejected(t *testing.T) {
+	messageCache := NewMessageCache(NewGroupedCache[discord.Message](FlagsNone, FlagMessages, nil)).(*messageCacheImpl)
+
+	messageCache.AddMessage(newTestMessage(1, 10, 100))
+	assert.Empty(t, messageCache.guildChannels)
+	assert.Empty(t, messageCache.channelGuilds)
+}
+
+func TestMessageCache_GuildIndexEviction(t *testing.T) {
+	messageCache := NewMessageCache(NewBoundedGroupedCache[discord.Message](FlagsAll, FlagMessages, nil, nil, WithMaxSize(1))).(*messageCacheImpl)
+
+	messageCache.AddMessage(newTestMessage(1, 10, 100))
+	// evicts the only message of channel 10
+	messageCache.AddMessage(newTestMessage(2, 20, 200))
+	assert.NotContains(t, messageCache.guildChannels, snowflake.ID(1))
+	assert.NotContains(t, messageCache.channelGuilds, snowflake.ID(10))
+	assert.Contains(t, messageCache.guildChannels, snowflake.ID(2))
+}
@@ -0,0 +1,345 @@
+package cache
+
+import (
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ Cache[any]        = (*KVCache[any])(nil)
+	_ StatsProvider     = (*KVCache[any])(nil)
+	_ GroupedCache[any] = (*KVGroupedCache[any])(nil)
+	_ StatsProvider     = (*KVGroupedCache[any])(nil)
+)
+
+// NewKVCache returns a new Cache which stores its entities serialized in the given KVStore under the given namespace.
+// If codec is nil DefaultCodec is used.
+// As the Cache interface does not return errors, errors from the KVStore & Codec are logged via the configured logger.
+func NewKVCache[T any](store KVStore, namespace string, codec Codec[T], flags Flags, neededFlags Flags, policy Policy[T], opts ...KVCacheConfigOpt) Cache[T] {
+	config := DefaultKVCacheConfig()
+	config.Apply(opts)
+	if codec == nil {
+		codec = DefaultCodec[T]()
+	}
+
+	return &KVCache[T]{
+		config:      *config,
+		store:       store,
+		prefix:      kvKey(config.KeyPrefix, namespace),
+		codec:       codec,
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+// KVCache is a Cache backed by a KVStore. This allows multiple processes to share the same cache.
+// Entities are stored as <key prefix>:<namespace>:<id>.
+type KVCache[T any] struct {
+	cacheCounters
+	config      KVCacheConfig
+	store       KVStore
+	prefix      string
+	codec       Codec[T]
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+}
+
+func (c *KVCache[T]) key(id snowflake.ID) string {
+	return kvKey(c.prefix, id.String())
+}
+
+func (c *KVCache[T]) Get(id snowflake.ID) (T, bool) {
+	entity, ok := kvGet(c.config, c.store, c.codec, c.key(id))
+	c.countGet(ok)
+	return entity, ok
+}
+
+func (c *KVCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		c.countPolicyRejection()
+		return
+	}
+	kvPut(c.config, c.store, c.codec, c.key(id), entity)
+}
+
+func (c *KVCache[T]) Remove(id snowflake.ID) (T, bool) {
+	return kvRemove(c.config, c.store, c.codec, c.key(id))
+}
+
+func (c *KVCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	kvRemoveIf(c.config, c.store, c.codec, c.prefix+":", func(_ string, entity T) bool {
+		return filterFunc(entity)
+	})
+}
+
+func (c *KVCache[T]) Len() int {
+	return kvLen(c.config, c.store, c.prefix+":")
+}
+
+// Stats returns the CacheStats of this cache. The size is not known for KVCache(s) and therefore always 0.
+func (c *KVCache[T]) Stats() CacheStats {
+	return c.stats(c.neededFlags, c.Len(), 0)
+}
+
+func (c *KVCache[T]) ForEach(forEachFunc func(entity T)) {
+	kvForEach(c.config, c.store, c.codec, c.prefix+":", func(_ string, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+// NewKVGroupedCache returns a new GroupedCache which stores its entities serialized in the given KVStore under the given namespace.
+// If codec is nil DefaultCodec is used.
+// As the GroupedCache interface does not return errors, errors from the KVStore & Codec are logged via the configured logger.
+func NewKVGroupedCache[T any](store KVStore, namespace string, codec Codec[T], flags Flags, neededFlags Flags, policy Policy[T], opts ...KVCacheConfigOpt) GroupedCache[T] {
+	config := DefaultKVCacheConfig()
+	config.Apply(opts)
+	if codec == nil {
+		codec = DefaultCodec[T]()
+	}
+
+	return &KVGroupedCache[T]{
+		config:      *config,
+		store:       store,
+		prefix:      kvKey(config.KeyPrefix, namespace),
+		codec:       codec,
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+// KVGroupedCache is a GroupedCache backed by a KVStore. This allows multiple processes to share the same cache.
+// Entities are stored as <key prefix>:<namespace>:<group id>:<id>.
+type KVGroupedCache[T any] struct {
+	cacheCounters
+	config      KVCacheConfig
+	store       KVStore
+	prefix      string
+	codec       Codec[T]
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+}
+
+func (c *KVGroupedCache[T]) groupPrefix(groupID snowflake.ID) string {
+	return kvKey(c.prefix, groupID.String()) + ":"
+}
+
+func (c *KVGroupedCache[T]) key(groupID snowflake.ID, id snowflake.ID) string {
+	return c.groupPrefix(groupID) + id.String()
+}
+
+// groupID parses the group id out of the given key.
+func (c *KVGroupedCache[T]) groupID(key string) snowflake.ID {
+	rest := strings.TrimPrefix(key, c.prefix+":")
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		rest = rest[:i]
+	}
+	groupID, err := snowflake.Parse(rest)
+	if err != nil {
+		c.config.Logger.Errorf("failed to parse group id from key %s: %s", key, err)
+	}
+	return groupID
+}
+
+func (c *KVGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := kvGet(c.config, c.store, c.codec, c.key(groupID, id))
+	c.countGet(ok)
+	return entity, ok
+}
+
+func (c *KVGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		c.countPolicyRejection()
+		return
+	}
+	kvPut(c.config, c.store, c.codec, c.key(groupID, id), entity)
+}
+
+func (c *KVGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return kvRemove(c.config, c.store, c.codec, c.key(groupID, id))
+}
+
+func (c *KVGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	kvRemoveKeys(c.config, c.store, c.groupPrefix(groupID))
+}
+
+func (c *KVGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	kvRemoveIf(c.config, c.store, c.codec, c.prefix+":", func(key string, entity T) bool {
+		return filterFunc(c.groupID(key), entity)
+	})
+}
+
+func (c *KVGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	kvRemoveIf(c.config, c.store, c.codec, c.groupPrefix(groupID), func(_ string, entity T) bool {
+		return filterFunc(groupID, entity)
+	})
+}
+
+func (c *KVGroupedCache[T]) Len() int {
+	return kvLen(c.config, c.store, c.prefix+":")
+}
+
+func (c *KVGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return kvLen(c.config, c.store, c.groupPrefix(groupID))
+}
+
+func (c *KVGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	kvForEach(c.config, c.store, c.codec, c.prefix+":", func(key string, entity T) {
+		forEachFunc(c.groupID(key), entity)
+	})
+}
+
+func (c *KVGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	kvForEach(c.config, c.store, c.codec, c.groupPrefix(groupID), func(_ string, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+// Stats returns the CacheStats of this cache. The size is not known for KVGroupedCache(s) and therefore always 0.
+func (c *KVGroupedCache[T]) Stats() CacheStats {
+	return c.stats(c.neededFlags, c.Len(), 0)
+}
+
+func kvKey(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ":")
+}
+
+func kvGet[T any](config KVCacheConfig, store KVStore, codec Codec[T], key string) (T, bool) {
+	var entity T
+	data, ok, err := store.Get(key)
+	if err != nil {
+		config.Logger.Errorf("failed to get key %s from kv store: %s", key, err)
+		return entity, false
+	}
+	if !ok {
+		return entity, false
+	}
+	if entity, err = codec.Decode(data); err != nil {
+		config.Logger.Errorf("failed to decode key %s: %s", key, err)
+		return entity, false
+	}
+	return entity, true
+}
+
+func kvPut[T any](config KVCacheConfig, store KVStore, codec Codec[T], key string, entity T) {
+	data, err := codec.Encode(entity)
+	if err != nil {
+		config.Logger.Errorf("failed to encode key %s: %s", key, err)
+		return
+	}
+	if err = store.Set(key, data); err != nil {
+		config.Logger.Errorf("failed to set key %s in kv store: %s", key, err)
+	}
+}
+
+// kvRemove removes the given key and returns the previous entity. The get & delete are not atomic.
+func kvRemove[T any](config KVCacheConfig, store KVStore, codec Codec[T], key string) (T, bool) {
+	entity, ok := kvGet(config, store, codec, key)
+	if !ok {
+		return entity, false
+	}
+	if err := store.Delete(key); err != nil {
+		config.Logger.Errorf("failed to delete key %s from kv store: %s", key, err)
+		return entity, false
+	}
+	return entity, true
+}
+
+// kvScan calls fn with batches of keys matching the given prefix.
+func kvScan(config KVCacheConfig, store KVStore, prefix string, fn func(keys []string)) {
+	var (
+		cursor uint64
+		keys   []string
+		err    error
+	)
+	for {
+		keys, cursor, err = store.Scan(prefix, cursor, config.BatchSize)
+		if err != nil {
+			config.Logger.Errorf("failed to scan prefix %s in kv store: %s", prefix, err)
+			return
+		}
+		if len(keys) > 0 {
+			fn(keys)
+		}
+		if cursor == 0 {
+			return
+		}
+	}
+}
+
+// kvForEach calls fn for every key matching the given prefix with its decoded entity. Entities are fetched in batches.
+func kvForEach[T any](config KVCacheConfig, store KVStore, codec Codec[T], prefix string, fn func(key string, entity T)) {
+	kvScan(config, store, prefix, func(keys []string) {
+		values, err := store.MGet(keys...)
+		if err != nil {
+			config.Logger.Errorf("failed to get keys with prefix %s from kv store: %s", prefix, err)
+			return
+		}
+		for i, data := range values {
+			if data == nil {
+				continue
+			}
+			entity, err := codec.Decode(data)
+			if err != nil {
+				config.Logger.Errorf("failed to decode key %s: %s", keys[i], err)
+				continue
+			}
+			fn(keys[i], entity)
+		}
+	})
+}
+
+func kvRemoveIf[T any](config KVCacheConfig, store KVStore, codec Codec[T], prefix string, filterFunc func(key string, entity T) bool) {
+	var keys []string
+	kvForEach(config, store, codec, prefix, func(key string, entity T) {
+		if filterFunc(key, entity) {
+			keys = append(keys, key)
+		}
+	})
+	kvDelete(config, store, keys)
+}
+
+func kvRemoveKeys(config KVCacheConfig, store KVStore, prefix string) {
+	var keys []string
+	kvScan(config, store, prefix, func(batch []string) {
+		keys = append(keys, batch...)
+	})
+	kvDelete(config, store, keys)
+}
+
+func kvDelete(config KVCacheConfig, store KVStore, keys []string) {
+	for len(keys) > 0 {
+		n := len(keys)
+		if config.BatchSize > 0 && n > config.BatchSize {
+			n = config.BatchSize
+		}
+		if err := store.Delete(keys[:n]...); err != nil {
+			config.Logger.Errorf("failed to delete keys from kv store: %s", err)
+		}
+		keys = keys[n:]
+	}
+}
+
+func kvLen(config KVCacheConfig, store KVStore, prefix string) int {
+	var length int
+	kvScan(config, store, prefix, func(keys []string) {
+		length += len(keys)
+	})
+	return length
+}
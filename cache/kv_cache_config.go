@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"github.com/disgoorg/log"
+)
+
+// DefaultKVCacheConfig returns a KVCacheConfig with sensible defaults.
+func DefaultKVCacheConfig() *KVCacheConfig {
+	return &KVCacheConfig{
+		Logger:    log.Default(),
+		KeyPrefix: "disgo",
+		BatchSize: 100,
+	}
+}
+
+// KVCacheConfig lets you configure your KVCache & KVGroupedCache.
+type KVCacheConfig struct {
+	Logger    log.Logger
+	KeyPrefix string
+	BatchSize int
+}
+
+// KVCacheConfigOpt is a type alias for a function that takes a KVCacheConfig and is used to configure your KVCache & KVGroupedCache.
+type KVCacheConfigOpt func(config *KVCacheConfig)
+
+// Apply applies the given KVCacheConfigOpt(s) to the KVCacheConfig
+func (c *KVCacheConfig) Apply(opts []KVCacheConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithKVLogger sets the Logger of the KVCacheConfig. It is used to log errors returned by the KVStore.
+func WithKVLogger(logger log.Logger) KVCacheConfigOpt {
+	return func(config *KVCacheConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithKVKeyPrefix sets the prefix all keys are namespaced with. This allows multiple independent bots to share one KVStore.
+func WithKVKeyPrefix(keyPrefix string) KVCacheConfigOpt {
+	return func(config *KVCacheConfig) {
+		config.KeyPrefix = keyPrefix
+	}
+}
+
+// WithKVBatchSize sets how many entities are fetched from the KVStore at once when iterating over the cache.
+func WithKVBatchSize(batchSize int) KVCacheConfigOpt {
+	return func(config *KVCacheConfig) {
+		config.BatchSize = batchSize
+	}
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestKVCache_GuildChannel(t *testing.T) {
+	var textChannel discord.GuildTextChannel
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"2","type":0,"guild_id":"1","name":"general"}`), &textChannel))
+	var voiceChannel discord.GuildVoiceChannel
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"3","type":2,"guild_id":"1","name":"voice","bitrate":64000}`), &voiceChannel))
+
+	store := NewMemoryKVStore()
+	c := NewKVCache[discord.GuildChannel](store, "channels", nil, FlagsAll, FlagChannels, nil, WithKVBatchSize(1))
+	c.Put(textChannel.ID(), textChannel)
+	c.Put(voiceChannel.ID(), voiceChannel)
+	assert.Equal(t, 2, c.Len())
+
+	channel, ok := c.Get(textChannel.ID())
+	assert.True(t, ok)
+	assert.Equal(t, textChannel, channel)
+
+	channel, ok = c.Get(voiceChannel.ID())
+	assert.True(t, ok)
+	assert.IsType(t, discord.GuildVoiceChannel{}, channel)
+	assert.Equal(t, 64000, channel.(discord.GuildVoiceChannel).Bitrate())
+
+	var count int
+	c.ForEach(func(_ discord.GuildChannel) {
+		count++
+	})
+	assert.Equal(t, 2, count)
+
+	c.RemoveIf(func(channel discord.GuildChannel) bool {
+		return channel.Type() == discord.ChannelTypeGuildVoice
+	})
+	_, ok = c.Get(voiceChannel.ID())
+	assert.False(t, ok)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestKVGroupedCache(t *testing.T) {
+	store := NewMemoryKVStore()
+	c := NewKVGroupedCache[discord.Role](store, "roles", nil, FlagsAll, FlagRoles, nil, WithKVKeyPrefix("test"))
+	c.Put(1, 10, discord.Role{ID: 10, GuildID: 1, Name: "a"})
+	c.Put(1, 11, discord.Role{ID: 11, GuildID: 1, Name: "b"})
+	c.Put(2, 20, discord.Role{ID: 20, GuildID: 2, Name: "c"})
+
+	_, ok, _ := store.Get("test:roles:1:10")
+	assert.True(t, ok)
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, 2, c.GroupLen(1))
+
+	groups := map[snowflake.ID]int{}
+	c.ForEach(func(groupID snowflake.ID, _ discord.Role) {
+		groups[groupID]++
+	})
+	assert.Equal(t, map[snowflake.ID]int{1: 2, 2: 1}, groups)
+
+	role, ok := c.Remove(1, 11)
+	assert.True(t, ok)
+	assert.Equal(t, "b", role.Name)
+
+	c.GroupRemove(1)
+	assert.Equal(t, 0, c.GroupLen(1))
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestKVGroupedCache_SharedMessages(t *testing.T) {
+	store := NewMemoryKVStore()
+	guildID := snowflake.ID(1)
+	// messages added by another process sharing the same store
+	other := NewMessageCache(NewKVGroupedCache[discord.Message](store, "messages", nil, FlagsAll, FlagMessages, nil))
+	other.AddMessage(discord.Message{ID: 10, ChannelID: 2, GuildID: &guildID})
+	other.AddMessage(discord.Message{ID: 11, ChannelID: 3})
+
+	messageCache := NewMessageCache(NewKVGroupedCache[discord.Message](store, "messages", nil, FlagsAll, FlagMessages, nil))
+	var count int
+	messageCache.GuildMessagesForEach(guildID, func(_ discord.Message) {
+		count++
+	})
+	assert.Equal(t, 1, count)
+
+	messageCache.RemoveMessagesByGuildID(guildID)
+	_, ok := messageCache.Message(2, 10)
+	assert.False(t, ok)
+	_, ok = messageCache.Message(3, 11)
+	assert.True(t, ok)
+}
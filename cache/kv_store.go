@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// KVStore is a minimal key value store which can be used as backend for KVCache & KVGroupedCache.
+// It is modeled after the commands most external stores like Redis provide, so it can be implemented by a thin wrapper around your client of choice.
+// Implementations must be thread safe.
+type KVStore interface {
+	// Get returns the value for the given key and a bool whether it was found or not.
+	Get(key string) ([]byte, bool, error)
+
+	// MGet returns the values for the given keys in the same order. Missing keys are returned as nil.
+	MGet(keys ...string) ([][]byte, error)
+
+	// Set stores the given value with the given key. If the key is already present, it will be overwritten.
+	Set(key string, value []byte) error
+
+	// Delete removes the given keys. Missing keys are ignored.
+	Delete(keys ...string) error
+
+	// Scan returns up to count keys starting with the given prefix beginning at the given cursor and the cursor to continue with.
+	// A returned cursor of 0 means the scan is complete. Keys may be returned more than once if the store is modified during a scan.
+	Scan(prefix string, cursor uint64, count int) ([]string, uint64, error)
+}
+
+var _ KVStore = (*MemoryKVStore)(nil)
+
+// NewMemoryKVStore returns a new in-memory KVStore. This is mostly useful for testing KVCache without an external store.
+func NewMemoryKVStore() *MemoryKVStore {
+	return &MemoryKVStore{
+		values: make(map[string][]byte),
+	}
+}
+
+// MemoryKVStore is a thread safe in-memory implementation of KVStore.
+type MemoryKVStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+func (s *MemoryKVStore) Get(key string) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+func (s *MemoryKVStore) MGet(keys ...string) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = s.values[key]
+	}
+	return values, nil
+}
+
+func (s *MemoryKVStore) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *MemoryKVStore) Delete(keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+	return nil
+}
+
+func (s *MemoryKVStore) Scan(prefix string, cursor uint64, count int) ([]string, uint64, error) {
+	s.mu.RLock()
+	var keys []string
+	for key := range s.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	s.mu.RUnlock()
+	sort.Strings(keys)
+
+	if cursor >= uint64(len(keys)) {
+		return nil, 0, nil
+	}
+	end := cursor + uint64(count)
+	if count <= 0 || end >= uint64(len(keys)) {
+		return keys[cursor:], 0, nil
+	}
+	return keys[cursor:end], end, nil
+}
+
+// Len returns the number of keys in the MemoryKVStore.
+func (s *MemoryKVStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.values)
+}
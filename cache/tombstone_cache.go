@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// TombstoneCache keeps removed entities for a short time after they got removed from the cache.
+// This allows delete events to still show what was removed, even if the entity was already removed from the cache by a previous event,
+// a bulk removal like a guild delete or got evicted by a bounded cache.
+type TombstoneCache interface {
+	// DeletedChannel returns the discord.GuildChannel which was removed from the ChannelCache within the configured tombstone TTL.
+	DeletedChannel(channelID snowflake.ID) (discord.GuildChannel, bool)
+	// AddDeletedChannel adds a tombstone for the given removed discord.GuildChannel.
+	AddDeletedChannel(channel discord.GuildChannel)
+
+	// DeletedRole returns the discord.Role which was removed from the RoleCache within the configured tombstone TTL.
+	DeletedRole(guildID snowflake.ID, roleID snowflake.ID) (discord.Role, bool)
+	// AddDeletedRole adds a tombstone for the given removed discord.Role.
+	AddDeletedRole(role discord.Role)
+
+	// DeletedMessage returns the discord.Message which was removed from the MessageCache within the configured tombstone TTL.
+	DeletedMessage(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool)
+	// AddDeletedMessage adds a tombstone for the given removed discord.Message.
+	AddDeletedMessage(message discord.Message)
+}
+
+// NewTombstoneCache returns a new TombstoneCache which keeps removed entities for the given ttl.
+// A ttl of 0 disables tombstones.
+func NewTombstoneCache(ttl time.Duration) TombstoneCache {
+	return &tombstoneCacheImpl{
+		channels: newTombstones[discord.GuildChannel](ttl),
+		roles:    newTombstones[discord.Role](ttl),
+		messages: newTombstones[discord.Message](ttl),
+	}
+}
+
+type tombstoneCacheImpl struct {
+	channels *tombstones[discord.GuildChannel]
+	roles    *tombstones[discord.Role]
+	messages *tombstones[discord.Message]
+}
+
+func (c *tombstoneCacheImpl) DeletedChannel(channelID snowflake.ID) (discord.GuildChannel, bool) {
+	return c.channels.get(0, channelID)
+}
+
+func (c *tombstoneCacheImpl) AddDeletedChannel(channel discord.GuildChannel) {
+	c.channels.add(0, channel.ID(), channel)
+}
+
+func (c *tombstoneCacheImpl) DeletedRole(guildID snowflake.ID, roleID snowflake.ID) (discord.Role, bool) {
+	return c.roles.get(guildID, roleID)
+}
+
+func (c *tombstoneCacheImpl) AddDeletedRole(role discord.Role) {
+	c.roles.add(role.GuildID, role.ID, role)
+}
+
+func (c *tombstoneCacheImpl) DeletedMessage(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
+	return c.messages.get(channelID, messageID)
+}
+
+func (c *tombstoneCacheImpl) AddDeletedMessage(message discord.Message) {
+	c.messages.add(message.ChannelID, message.ID, message)
+}
+
+type tombstoneKey struct {
+	groupID snowflake.ID
+	id      snowflake.ID
+}
+
+type tombstone[T any] struct {
+	key       tombstoneKey
+	entity    T
+	expiresAt time.Time
+}
+
+func newTombstones[T any](ttl time.Duration) *tombstones[T] {
+	return &tombstones[T]{
+		ttl:     ttl,
+		entries: make(map[tombstoneKey]tombstone[T]),
+	}
+}
+
+// tombstones keeps entities until their ttl expired. As all entities share the same ttl, the queue is ordered by expiry.
+type tombstones[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[tombstoneKey]tombstone[T]
+	queue   []tombstone[T]
+}
+
+func (t *tombstones[T]) expire(now time.Time) {
+	var i int
+	for ; i < len(t.queue) && now.After(t.queue[i].expiresAt); i++ {
+		// only delete the entry if it was not re-added in the meantime
+		if entry, ok := t.entries[t.queue[i].key]; ok && !now.Before(entry.expiresAt) {
+			delete(t.entries, t.queue[i].key)
+		}
+	}
+	t.queue = t.queue[i:]
+}
+
+func (t *tombstones[T]) add(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if t.ttl <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.expire(now)
+	entry := tombstone[T]{
+		key:       tombstoneKey{groupID: groupID, id: id},
+		entity:    entity,
+		expiresAt: now.Add(t.ttl),
+	}
+	t.entries[entry.key] = entry
+	t.queue = append(t.queue, entry)
+}
+
+func (t *tombstones[T]) get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.expire(time.Now())
+	entry, ok := t.entries[tombstoneKey{groupID: groupID, id: id}]
+	return entry.entity, ok
+}
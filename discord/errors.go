@@ -10,8 +10,11 @@ var (
 	ErrNoShardManager          = errors.New("no shard manager configured")
 	ErrNoGateway               = errors.New("no gateway configured")
 	ErrGatewayAlreadyConnected = errors.New("gateway is already connected")
+	ErrGatewayReconnectGaveUp  = errors.New("gave up reconnecting gateway")
 	ErrShardNotConnected       = errors.New("shard is not connected")
 	ErrShardNotFound           = errors.New("shard not found in shard manager")
+	ErrShardNoSession          = errors.New("shard has no session which could be handed over")
+	ErrShardsCoordinated       = errors.New("shards are assigned by the coordinator and can't be re-sharded")
 	ErrGatewayCompressedData   = errors.New("disgo does not currently support compressed gateway data")
 	ErrNoHTTPServer            = errors.New("no http server configured")
 
@@ -0,0 +1,31 @@
+package events
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+)
+
+// NewMessageCollector returns a new bot.EventCollectorBuilder which collects MessageCreate events in the given channel.
+func NewMessageCollector(client bot.Client, channelID snowflake.ID) *bot.EventCollectorBuilder[*MessageCreate] {
+	return bot.NewEventCollectorBuilder[*MessageCreate](client).
+		AddFilter(func(e *MessageCreate) bool {
+			return e.ChannelID == channelID
+		})
+}
+
+// NewReactionCollector returns a new bot.EventCollectorBuilder which collects MessageReactionAdd events on the given message.
+func NewReactionCollector(client bot.Client, messageID snowflake.ID) *bot.EventCollectorBuilder[*MessageReactionAdd] {
+	return bot.NewEventCollectorBuilder[*MessageReactionAdd](client).
+		AddFilter(func(e *MessageReactionAdd) bool {
+			return e.MessageID == messageID
+		})
+}
+
+// NewComponentCollector returns a new bot.EventCollectorBuilder which collects ComponentInteractionCreate events of components on the given message.
+func NewComponentCollector(client bot.Client, messageID snowflake.ID) *bot.EventCollectorBuilder[*ComponentInteractionCreate] {
+	return bot.NewEventCollectorBuilder[*ComponentInteractionCreate](client).
+		AddFilter(func(e *ComponentInteractionCreate) bool {
+			return e.Message.ID == messageID
+		})
+}
@@ -12,3 +12,59 @@ type Ready struct {
 type Resumed struct {
 	*GenericEvent
 }
+
+// GatewayStatusChange is called for every gateway.StatusChange of a gateway.Gateway.
+// Additionally, one of the more specific events below is called depending on the gateway.StatusChangeReason.
+type GatewayStatusChange struct {
+	*GenericEvent
+	gateway.StatusChange
+}
+
+// GatewayConnecting is called when a gateway.Gateway starts connecting to discord
+type GatewayConnecting struct {
+	*GatewayStatusChange
+}
+
+// GatewayConnectFailed is called when a gateway.Gateway failed to connect to discord
+type GatewayConnectFailed struct {
+	*GatewayStatusChange
+}
+
+// GatewayConnected is called when a gateway.Gateway established the websocket connection to discord
+type GatewayConnected struct {
+	*GatewayStatusChange
+}
+
+// GatewayIdentifySent is called when a gateway.Gateway sent an identify and waits for the Ready event
+type GatewayIdentifySent struct {
+	*GatewayStatusChange
+}
+
+// GatewayResumeSent is called when a gateway.Gateway sent a resume and waits for the Resumed event
+type GatewayResumeSent struct {
+	*GatewayStatusChange
+}
+
+// GatewayInvalidSession is called when discord invalidated the session of a gateway.Gateway.
+// CanResume tells whether the session will be resumed or a new one is identified.
+type GatewayInvalidSession struct {
+	*GatewayStatusChange
+}
+
+// GatewayDisconnected is called when the connection of a gateway.Gateway was closed.
+// The Reason, CloseCode and Err tell why the connection was closed.
+type GatewayDisconnected struct {
+	*GatewayStatusChange
+}
+
+// GatewayReconnectScheduled is called when a gateway.Gateway schedules a reconnect attempt after Delay
+type GatewayReconnectScheduled struct {
+	*GatewayStatusChange
+}
+
+// GatewayLatencySample is called periodically with the latency of a gateway.Gateway.
+// See gateway.WithLatencySampleInterval to configure the interval.
+type GatewayLatencySample struct {
+	*GenericEvent
+	gateway.EventLatencySample
+}
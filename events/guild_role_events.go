@@ -25,7 +25,8 @@ type RoleUpdate struct {
 	OldRole discord.Role
 }
 
-// RoleDelete indicates that a discord.Role got deleted
+// RoleDelete indicates that a discord.Role got deleted.
+// Role is only set if it was cached or still available as tombstone (see cache.WithTombstoneTTL).
 type RoleDelete struct {
 	*GenericRole
 }
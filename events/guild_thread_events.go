@@ -28,6 +28,7 @@ type ThreadUpdate struct {
 }
 
 // ThreadDelete is dispatched when a thread is deleted.
+// Thread is only set if it was cached or still available as tombstone (see cache.WithTombstoneTTL).
 type ThreadDelete struct {
 	*GenericThread
 }
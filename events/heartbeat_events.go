@@ -0,0 +1,18 @@
+package events
+
+import (
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// HeartbeatAck is called when discord acknowledged a heartbeat of a gateway.Gateway
+type HeartbeatAck struct {
+	*GenericEvent
+	gateway.EventHeartbeatAck
+}
+
+// ZombieConnection is called when discord didn't acknowledge the last heartbeat of a gateway.Gateway in time.
+// The gateway.Gateway closes the connection and resumes the session afterwards.
+type ZombieConnection struct {
+	*GenericEvent
+	gateway.EventZombieConnection
+}
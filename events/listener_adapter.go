@@ -11,6 +11,26 @@ type ListenerAdapter struct {
 	// raw event
 	OnRaw func(event *Raw)
 
+	// Heartbeat Events
+	OnHeartbeatAck     func(event *HeartbeatAck)
+	OnZombieConnection func(event *ZombieConnection)
+
+	// Reconnect Events
+	OnReconnectAttempt func(event *ReconnectAttempt)
+	OnReconnectResult  func(event *ReconnectResult)
+
+	// Gateway Status Events
+	OnGatewayStatusChange       func(event *GatewayStatusChange)
+	OnGatewayConnecting         func(event *GatewayConnecting)
+	OnGatewayConnectFailed      func(event *GatewayConnectFailed)
+	OnGatewayConnected          func(event *GatewayConnected)
+	OnGatewayIdentifySent       func(event *GatewayIdentifySent)
+	OnGatewayResumeSent         func(event *GatewayResumeSent)
+	OnGatewayInvalidSession     func(event *GatewayInvalidSession)
+	OnGatewayDisconnected       func(event *GatewayDisconnected)
+	OnGatewayReconnectScheduled func(event *GatewayReconnectScheduled)
+	OnGatewayLatencySample      func(event *GatewayLatencySample)
+
 	// GuildApplicationCommandPermissionsUpdate
 	OnGuildApplicationCommandPermissionsUpdate func(event *GuildApplicationCommandPermissionsUpdate)
 
@@ -175,6 +195,68 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	// Heartbeat Events
+	case *HeartbeatAck:
+		if listener := l.OnHeartbeatAck; listener != nil {
+			listener(e)
+		}
+	case *ZombieConnection:
+		if listener := l.OnZombieConnection; listener != nil {
+			listener(e)
+		}
+
+	// Reconnect Events
+	case *ReconnectAttempt:
+		if listener := l.OnReconnectAttempt; listener != nil {
+			listener(e)
+		}
+	case *ReconnectResult:
+		if listener := l.OnReconnectResult; listener != nil {
+			listener(e)
+		}
+
+	// Gateway Status Events
+	case *GatewayStatusChange:
+		if listener := l.OnGatewayStatusChange; listener != nil {
+			listener(e)
+		}
+	case *GatewayConnecting:
+		if listener := l.OnGatewayConnecting; listener != nil {
+			listener(e)
+		}
+	case *GatewayConnectFailed:
+		if listener := l.OnGatewayConnectFailed; listener != nil {
+			listener(e)
+		}
+	case *GatewayConnected:
+		if listener := l.OnGatewayConnected; listener != nil {
+			listener(e)
+		}
+	case *GatewayIdentifySent:
+		if listener := l.OnGatewayIdentifySent; listener != nil {
+			listener(e)
+		}
+	case *GatewayResumeSent:
+		if listener := l.OnGatewayResumeSent; listener != nil {
+			listener(e)
+		}
+	case *GatewayInvalidSession:
+		if listener := l.OnGatewayInvalidSession; listener != nil {
+			listener(e)
+		}
+	case *GatewayDisconnected:
+		if listener := l.OnGatewayDisconnected; listener != nil {
+			listener(e)
+		}
+	case *GatewayReconnectScheduled:
+		if listener := l.OnGatewayReconnectScheduled; listener != nil {
+			listener(e)
+		}
+	case *GatewayLatencySample:
+		if listener := l.OnGatewayLatencySample; listener != nil {
+			listener(e)
+		}
+
 	case *GuildApplicationCommandPermissionsUpdate:
 		if listener := l.OnGuildApplicationCommandPermissionsUpdate; listener != nil {
 			listener(e)
@@ -39,7 +39,8 @@ type MessageUpdate struct {
 	OldMessage discord.Message
 }
 
-// MessageDelete indicates that a discord.Message got deleted
+// MessageDelete indicates that a discord.Message got deleted.
+// Message is only set if it was cached or still available as tombstone (see cache.WithTombstoneTTL).
 type MessageDelete struct {
 	*GenericMessage
 }
@@ -0,0 +1,18 @@
+package events
+
+import (
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ReconnectAttempt is called before a gateway.Gateway tries to reconnect after waiting for the delay of its gateway.Backoff
+type ReconnectAttempt struct {
+	*GenericEvent
+	gateway.EventReconnectAttempt
+}
+
+// ReconnectResult is called after a gateway.Gateway tried to reconnect.
+// If GaveUp is true the gateway.Gateway stays disconnected.
+type ReconnectResult struct {
+	*GenericEvent
+	gateway.EventReconnectResult
+}
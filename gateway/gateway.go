@@ -72,6 +72,10 @@ type Gateway interface {
 	// This may be nil if the Gateway was never connected to Discord, was gracefully closed with websocket.CloseNormalClosure or websocket.CloseGoingAway.
 	SessionID() *string
 
+	// ResumeURL returns the URL discord wants the Gateway to use when resuming the session.
+	// This may be nil if the Gateway was never connected to Discord, was gracefully closed with websocket.CloseNormalClosure or websocket.CloseGoingAway.
+	ResumeURL() *string
+
 	// LastSequenceReceived returns the last sequence number that was received by the Gateway.
 	// This may be nil if the Gateway was never connected to Discord, was gracefully closed with websocket.CloseNormalClosure or websocket.CloseGoingAway.
 	LastSequenceReceived() *int
@@ -83,6 +87,7 @@ type Gateway interface {
 	Open(ctx context.Context) error
 
 	// Close gracefully closes the Gateway with the websocket.CloseNormalClosure code.
+	// This invalidates the session and deletes it from the SessionStore. Use CloseWithCode with websocket.CloseServiceRestart to keep it.
 	// If the context is done, the Gateway connection will be killed.
 	Close(ctx context.Context)
 
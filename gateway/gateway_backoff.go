@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff decides how long the Gateway waits before trying to (re)connect.
+type Backoff interface {
+	// Delay returns how long to wait before the given attempt and whether the attempt should be made at all.
+	// The first attempt is 0.
+	Delay(attempt int) (time.Duration, bool)
+}
+
+var _ Backoff = (*exponentialBackoff)(nil)
+
+// NewExponentialBackoff returns a Backoff which doubles the delay after every failed attempt, starting at base up to max.
+// A random jitter of up to half the delay is subtracted to avoid many shards reconnecting at the same time.
+// The first attempt is made immediately. maxAttempts of 0 retries forever.
+func NewExponentialBackoff(base time.Duration, max time.Duration, maxAttempts int) Backoff {
+	return &exponentialBackoff{
+		base:        base,
+		max:         max,
+		maxAttempts: maxAttempts,
+	}
+}
+
+type exponentialBackoff struct {
+	base        time.Duration
+	max         time.Duration
+	maxAttempts int
+}
+
+func (b *exponentialBackoff) Delay(attempt int) (time.Duration, bool) {
+	if b.maxAttempts > 0 && attempt >= b.maxAttempts {
+		return 0, false
+	}
+	if attempt == 0 {
+		return 0, true
+	}
+
+	delay := b.base
+	for i := 1; i < attempt && delay < b.max; i++ {
+		delay *= 2
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	return delay - time.Duration(rand.Int63n(int64(delay)/2+1)), true
+}
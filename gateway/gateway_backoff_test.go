@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := NewExponentialBackoff(time.Second, 8*time.Second, 6)
+
+	delay, ok := backoff.Delay(0)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+
+	for attempt, maxDelay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		delay, ok = backoff.Delay(attempt + 1)
+		assert.True(t, ok)
+		assert.LessOrEqual(t, delay, maxDelay)
+		assert.GreaterOrEqual(t, delay, maxDelay/2)
+	}
+
+	_, ok = backoff.Delay(6)
+	assert.False(t, ok)
+}
@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// TransportCompression is the compression of the whole gateway connection.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+type TransportCompression int
+
+const (
+	// TransportCompressionNone disables transport compression. Payloads can still be compressed via Config.Compress.
+	TransportCompressionNone TransportCompression = iota
+	// TransportCompressionZlibStream compresses the whole connection with a single zlib stream.
+	// This saves a lot of bandwidth and CPU compared to per payload compression.
+	TransportCompressionZlibStream
+)
+
+// query returns the value of the compress query parameter
+func (c TransportCompression) query() string {
+	switch c {
+	case TransportCompressionZlibStream:
+		return "zlib-stream"
+	default:
+		return ""
+	}
+}
+
+// zlibSuffix is appended by discord to every complete message of a zlib-stream
+var zlibSuffix = []byte{0x00, 0x00, 0xff, 0xff}
+
+// errIncompleteMessage is returned by a decompressor if the message is split over multiple websocket frames
+var errIncompleteMessage = errors.New("incomplete message")
+
+// decompressor decompresses binary websocket messages. A new decompressor is used for every connection.
+type decompressor interface {
+	// decompress returns the decompressed data. The returned data is only valid until the next call.
+	decompress(data []byte) ([]byte, error)
+}
+
+func newDecompressor(compression TransportCompression) decompressor {
+	if compression == TransportCompressionZlibStream {
+		return &zlibStreamDecompressor{}
+	}
+	return &zlibPayloadDecompressor{}
+}
+
+// zlibPayloadDecompressor decompresses payloads which are compressed on their own.
+type zlibPayloadDecompressor struct {
+	buf bytes.Buffer
+}
+
+func (d *zlibPayloadDecompressor) decompress(data []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress zlib: %w", err)
+	}
+	defer reader.Close()
+
+	d.buf.Reset()
+	if _, err = d.buf.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("failed to read decompressed data: %w", err)
+	}
+	return d.buf.Bytes(), nil
+}
+
+// zlibStreamReadSize is bigger than the flate window, so every read returns all data decompressed so far.
+const zlibStreamReadSize = 64 * 1024
+
+// zlibStreamDecompressor shares one inflate context for the whole connection.
+type zlibStreamDecompressor struct {
+	// in holds the compressed data until a complete message is received
+	in     bytes.Buffer
+	out    bytes.Buffer
+	chunk  []byte
+	reader io.ReadCloser
+}
+
+func (d *zlibStreamDecompressor) decompress(data []byte) ([]byte, error) {
+	d.in.Write(data)
+	if !bytes.HasSuffix(data, zlibSuffix) {
+		return nil, errIncompleteMessage
+	}
+
+	if d.reader == nil {
+		// the zlib header is only sent with the first message
+		reader, err := zlib.NewReader(&d.in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zlib-stream reader: %w", err)
+		}
+		d.reader = reader
+		d.chunk = make([]byte, zlibStreamReadSize)
+	}
+
+	d.out.Reset()
+	// the inflate context must never read past the flush as it would return io.ErrUnexpectedEOF and can't be used anymore afterwards
+	for d.in.Len() > 0 {
+		n, err := d.reader.Read(d.chunk)
+		d.out.Write(d.chunk[:n])
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to decompress zlib-stream: %w", err)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return d.out.Bytes(), nil
+}
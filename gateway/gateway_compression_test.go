@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordZlibStream compresses the given messages the same way discord does for a zlib-stream connection.
+func recordZlibStream(t *testing.T, messages []string) [][]byte {
+	var (
+		buf    bytes.Buffer
+		frames [][]byte
+	)
+	writer := zlib.NewWriter(&buf)
+	for _, message := range messages {
+		_, err := writer.Write([]byte(message))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Flush())
+		frames = append(frames, append([]byte(nil), buf.Bytes()...))
+		buf.Reset()
+	}
+	return frames
+}
+
+func TestZlibStreamDecompressor(t *testing.T) {
+	messages := []string{
+		`{"op":10,"d":{"heartbeat_interval":41250}}`,
+		`{"op":11}`,
+		`{"op":0,"t":"GUILD_CREATE","s":2,"d":{"members":"` + strings.Repeat("a", 200*1024) + `"}}`,
+		`{"op":11}`,
+	}
+	frames := recordZlibStream(t, messages)
+
+	d := newDecompressor(TransportCompressionZlibStream)
+	for i, frame := range frames {
+		if i == 2 {
+			// discord may split a message over multiple frames
+			_, err := d.decompress(frame[:len(frame)/2])
+			assert.ErrorIs(t, err, errIncompleteMessage)
+			frame = frame[len(frame)/2:]
+		}
+		data, err := d.decompress(frame)
+		assert.NoError(t, err)
+		assert.Equal(t, messages[i], string(data))
+	}
+}
+
+func TestZlibPayloadDecompressor(t *testing.T) {
+	var buf bytes.Buffer
+	writer := zlib.NewWriter(&buf)
+	_, _ = writer.Write([]byte(`{"op":11}`))
+	_ = writer.Close()
+
+	data, err := newDecompressor(TransportCompressionNone).decompress(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"op":11}`, string(data))
+}
@@ -1,6 +1,8 @@
 package gateway
 
 import (
+	"time"
+
 	"github.com/disgoorg/log"
 	"github.com/gorilla/websocket"
 )
@@ -8,16 +10,19 @@ import (
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
-		Logger:          log.Default(),
-		Dialer:          websocket.DefaultDialer,
-		LargeThreshold:  50,
-		Intents:         IntentsDefault,
-		Compress:        true,
-		URL:             "wss://gateway.discord.gg",
-		ShardID:         0,
-		ShardCount:      1,
-		AutoReconnect:   true,
-		EnableResumeURL: true,
+		Logger:                log.Default(),
+		Dialer:                websocket.DefaultDialer,
+		LargeThreshold:        50,
+		Intents:               IntentsDefault,
+		Compress:              true,
+		Encoding:              EncodingJSON,
+		URL:                   "wss://gateway.discord.gg",
+		ShardID:               0,
+		ShardCount:            1,
+		AutoReconnect:         true,
+		Backoff:               NewExponentialBackoff(time.Second, 30*time.Second, 0),
+		EnableResumeURL:       true,
+		LatencySampleInterval: time.Minute,
 	}
 }
 
@@ -28,13 +33,19 @@ type Config struct {
 	LargeThreshold            int
 	Intents                   Intents
 	Compress                  bool
+	TransportCompression      TransportCompression
+	Encoding                  Encoding
 	URL                       string
 	ShardID                   int
 	ShardCount                int
 	SessionID                 *string
 	ResumeURL                 *string
 	LastSequenceReceived      *int
+	SessionStore              SessionStore
 	AutoReconnect             bool
+	Backoff                   Backoff
+	StatusChangeFunc          StatusChangeFunc
+	LatencySampleInterval     time.Duration
 	EnableRawEvents           bool
 	EnableResumeURL           bool
 	RateLimiter               RateLimiter
@@ -96,6 +107,23 @@ func WithCompress(compress bool) ConfigOpt {
 	}
 }
 
+// WithTransportCompression sets the TransportCompression of the Gateway connection.
+// Per payload compression via WithCompress is disabled automatically if transport compression is used.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+func WithTransportCompression(compression TransportCompression) ConfigOpt {
+	return func(config *Config) {
+		config.TransportCompression = compression
+	}
+}
+
+// WithEncoding sets the Encoding of the Gateway connection.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+func WithEncoding(encoding Encoding) ConfigOpt {
+	return func(config *Config) {
+		config.Encoding = encoding
+	}
+}
+
 // WithURL sets the Gateway URL for the Gateway.
 func WithURL(url string) ConfigOpt {
 	return func(config *Config) {
@@ -127,6 +155,14 @@ func WithSessionID(sessionID string) ConfigOpt {
 	}
 }
 
+// WithResumeURL sets the URL used to resume the session of the Gateway.
+// It is only used if EnableResumeURL is true and the session can be resumed.
+func WithResumeURL(resumeURL string) ConfigOpt {
+	return func(config *Config) {
+		config.ResumeURL = &resumeURL
+	}
+}
+
 // WithSequence sets the last sequence received for the Gateway.
 // If sessionID and lastSequence is present while connecting, the Gateway will try to resume the session.
 func WithSequence(sequence int) ConfigOpt {
@@ -135,6 +171,14 @@ func WithSequence(sequence int) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the SessionStore which persists the session of the Gateway.
+// If no session is configured via WithSessionID & WithSequence, the Gateway resumes the stored session on the first connect.
+func WithSessionStore(sessionStore SessionStore) ConfigOpt {
+	return func(config *Config) {
+		config.SessionStore = sessionStore
+	}
+}
+
 // WithAutoReconnect sets whether the Gateway should automatically reconnect to Discord.
 func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 	return func(config *Config) {
@@ -142,6 +186,29 @@ func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 	}
 }
 
+// WithBackoff sets the Backoff which decides how long to wait between (re)connect attempts.
+// Once the Backoff gives up, the CloseHandlerFunc is called with discord.ErrGatewayReconnectGaveUp.
+func WithBackoff(backoff Backoff) ConfigOpt {
+	return func(config *Config) {
+		config.Backoff = backoff
+	}
+}
+
+// WithStatusChangeFunc sets the StatusChangeFunc which is called whenever the Status of the Gateway changes.
+func WithStatusChangeFunc(statusChangeFunc StatusChangeFunc) ConfigOpt {
+	return func(config *Config) {
+		config.StatusChangeFunc = statusChangeFunc
+	}
+}
+
+// WithLatencySampleInterval sets how often the Gateway dispatches an EventTypeLatencySample while connected.
+// An interval of 0 disables latency samples.
+func WithLatencySampleInterval(interval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.LatencySampleInterval = interval
+	}
+}
+
 // WithEnableRawEvents enables/disables the EventTypeRaw.
 func WithEnableRawEvents(enableRawEventEvents bool) ConfigOpt {
 	return func(config *Config) {
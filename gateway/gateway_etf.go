@@ -0,0 +1,631 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/disgoorg/json"
+)
+
+// Encoding is the encoding the gateway uses to send & receive messages.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+type Encoding string
+
+const (
+	// EncodingJSON encodes messages as JSON.
+	EncodingJSON Encoding = "json"
+	// EncodingETF encodes messages in the Erlang External Term Format, which is smaller & faster to parse for high volume shards.
+	EncodingETF Encoding = "etf"
+)
+
+// ETF tags (https://www.erlang.org/doc/apps/erts/erl_ext_dist.html)
+const (
+	etfVersion        = 131
+	etfCompressed     = 80
+	etfNewFloat       = 70
+	etfSmallInteger   = 97
+	etfInteger        = 98
+	etfFloat          = 99
+	etfAtom           = 100
+	etfSmallTuple     = 104
+	etfLargeTuple     = 105
+	etfNil            = 106
+	etfString         = 107
+	etfList           = 108
+	etfBinary         = 109
+	etfSmallBig       = 110
+	etfLargeBig       = 111
+	etfSmallAtom      = 115
+	etfMap            = 116
+	etfAtomUTF8       = 118
+	etfSmallAtomUTF8  = 119
+	etfMaxSafeInteger = 1<<53 - 1
+)
+
+var errETFUnexpectedEnd = errors.New("unexpected end of etf data")
+
+// etfAtomValue is an erlang atom. The atoms nil, null, true & false are decoded as nil & bool instead.
+type etfAtomValue string
+
+type etfDecoder struct {
+	data []byte
+	pos  int
+}
+
+// newETFDecoder returns an etfDecoder for the term in the given ETF data. Compressed terms are decompressed first.
+func newETFDecoder(data []byte) (*etfDecoder, error) {
+	if len(data) == 0 || data[0] != etfVersion {
+		return nil, errors.New("invalid etf version")
+	}
+	d := &etfDecoder{data: data, pos: 1}
+	if len(data) > 1 && data[1] == etfCompressed {
+		d.pos++
+		return d.decompress()
+	}
+	return d, nil
+}
+
+// decompress returns an etfDecoder for the compressed term following the compressed tag.
+func (d *etfDecoder) decompress() (*etfDecoder, error) {
+	size, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(d.data[d.pos:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress etf term: %w", err)
+	}
+	defer reader.Close()
+	data := make([]byte, size)
+	if _, err = io.ReadFull(reader, data); err != nil {
+		return nil, fmt.Errorf("failed to decompress etf term: %w", err)
+	}
+	d.pos = len(d.data)
+	return &etfDecoder{data: data}, nil
+}
+
+func (d *etfDecoder) read(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, errETFUnexpectedEnd
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *etfDecoder) readUint8() (int, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return int(b[0]), nil
+}
+
+func (d *etfDecoder) readUint16() (int, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *etfDecoder) readUint32() (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+// readAtom reads the atom following the given atom tag. The returned bytes are part of the data and must not be modified.
+func (d *etfDecoder) readAtom(tag int) ([]byte, error) {
+	var (
+		n   int
+		err error
+	)
+	if tag == etfSmallAtom || tag == etfSmallAtomUTF8 {
+		n, err = d.readUint8()
+	} else {
+		n, err = d.readUint16()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.read(n)
+}
+
+// readBinary reads the binary following the binary tag. The returned bytes are part of the data and must not be modified.
+func (d *etfDecoder) readBinary() ([]byte, error) {
+	n, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	return d.read(n)
+}
+
+// readNil consumes the next term and returns true if it is the nil or null atom. Otherwise, nothing is consumed.
+func (d *etfDecoder) readNil() bool {
+	start := d.pos
+	tag, err := d.readUint8()
+	if err == nil && isETFAtom(tag) {
+		if atom, err := d.readAtom(tag); err == nil && (string(atom) == "nil" || string(atom) == "null") {
+			return true
+		}
+	}
+	d.pos = start
+	return false
+}
+
+func isETFAtom(tag int) bool {
+	return tag == etfAtom || tag == etfAtomUTF8 || tag == etfSmallAtom || tag == etfSmallAtomUTF8
+}
+
+func isETFNumber(tag int) bool {
+	switch tag {
+	case etfSmallInteger, etfInteger, etfSmallBig, etfLargeBig, etfNewFloat, etfFloat:
+		return true
+	}
+	return false
+}
+
+// etfNumber is an ETF integer or float. Integers are kept as magnitude & sign, so they can be checked against any integer type.
+type etfNumber struct {
+	magnitude uint64
+	negative  bool
+	float     float64
+	isFloat   bool
+}
+
+// append appends the decimal representation of the number to b.
+func (n etfNumber) append(b []byte) []byte {
+	if n.isFloat {
+		return strconv.AppendFloat(b, n.float, 'g', -1, 64)
+	}
+	if n.negative {
+		b = append(b, '-')
+	}
+	return strconv.AppendUint(b, n.magnitude, 10)
+}
+
+// readNumber reads the number following the given integer or float tag.
+func (d *etfDecoder) readNumber(tag int) (etfNumber, error) {
+	switch tag {
+	case etfSmallInteger:
+		i, err := d.readUint8()
+		return etfNumber{magnitude: uint64(i)}, err
+
+	case etfInteger:
+		b, err := d.read(4)
+		if err != nil {
+			return etfNumber{}, err
+		}
+		i := int64(int32(binary.BigEndian.Uint32(b)))
+		if i < 0 {
+			return etfNumber{magnitude: uint64(-i), negative: true}, nil
+		}
+		return etfNumber{magnitude: uint64(i)}, nil
+
+	case etfNewFloat:
+		b, err := d.read(8)
+		if err != nil {
+			return etfNumber{}, err
+		}
+		return etfNumber{float: math.Float64frombits(binary.BigEndian.Uint64(b)), isFloat: true}, nil
+
+	case etfFloat:
+		b, err := d.read(31)
+		if err != nil {
+			return etfNumber{}, err
+		}
+		f, err := strconv.ParseFloat(string(bytes.TrimRight(b, "\x00")), 64)
+		if err != nil {
+			return etfNumber{}, fmt.Errorf("invalid etf float: %w", err)
+		}
+		return etfNumber{float: f, isFloat: true}, nil
+
+	case etfSmallBig, etfLargeBig:
+		var (
+			n   int
+			err error
+		)
+		if tag == etfSmallBig {
+			n, err = d.readUint8()
+		} else {
+			n, err = d.readUint32()
+		}
+		if err != nil {
+			return etfNumber{}, err
+		}
+		sign, err := d.readUint8()
+		if err != nil {
+			return etfNumber{}, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return etfNumber{}, err
+		}
+		if n > 8 {
+			return etfNumber{}, fmt.Errorf("etf big integer with %d bytes is too big", n)
+		}
+		var magnitude uint64
+		for i := n - 1; i >= 0; i-- {
+			magnitude = magnitude<<8 | uint64(b[i])
+		}
+		return etfNumber{magnitude: magnitude, negative: sign != 0 && magnitude != 0}, nil
+	}
+	return etfNumber{}, fmt.Errorf("etf tag %d is not a number", tag)
+}
+
+// readKey reads a map key, which discord sends as atom or binary. Integer keys are formatted as decimal.
+// The returned bytes may be part of the data and must not be modified.
+func (d *etfDecoder) readKey() ([]byte, error) {
+	tag, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case isETFAtom(tag):
+		return d.readAtom(tag)
+	case tag == etfBinary:
+		return d.readBinary()
+	case isETFNumber(tag):
+		n, err := d.readNumber(tag)
+		if err != nil {
+			return nil, err
+		}
+		return n.append(nil), nil
+	}
+	return nil, fmt.Errorf("unsupported etf map key tag: %d", tag)
+}
+
+// skip consumes the next term without decoding it.
+func (d *etfDecoder) skip() error {
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+
+	var n int
+	switch {
+	case isETFNumber(tag):
+		_, err = d.readNumber(tag)
+		return err
+
+	case isETFAtom(tag):
+		_, err = d.readAtom(tag)
+		return err
+
+	case tag == etfBinary:
+		_, err = d.readBinary()
+		return err
+
+	case tag == etfString:
+		if n, err = d.readUint16(); err != nil {
+			return err
+		}
+		_, err = d.read(n)
+		return err
+
+	case tag == etfNil:
+		return nil
+
+	case tag == etfList:
+		// the tail of the list is one more term
+		if n, err = d.readUint32(); err != nil {
+			return err
+		}
+		n++
+
+	case tag == etfSmallTuple:
+		n, err = d.readUint8()
+
+	case tag == etfLargeTuple:
+		n, err = d.readUint32()
+
+	case tag == etfMap:
+		n, err = d.readUint32()
+		n *= 2
+
+	default:
+		return fmt.Errorf("unsupported etf tag: %d", tag)
+	}
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err = d.skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decode decodes the next term into plain Go values: nil, bool, int64, uint64 for integers which don't fit into an int64,
+// float64, string for binaries, etfAtomValue, []any for lists & tuples and map[string]any.
+func (d *etfDecoder) decode() (any, error) {
+	tag, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case etfSmallInteger, etfInteger, etfSmallBig, etfLargeBig, etfNewFloat, etfFloat:
+		n, err := d.readNumber(tag)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case n.isFloat:
+			return n.float, nil
+		case n.negative:
+			if n.magnitude > 1<<63 {
+				return nil, errors.New("negative etf big integer is too big")
+			}
+			return -int64(n.magnitude), nil
+		case n.magnitude > math.MaxInt64:
+			return n.magnitude, nil
+		}
+		return int64(n.magnitude), nil
+
+	case etfAtom, etfAtomUTF8, etfSmallAtom, etfSmallAtomUTF8:
+		atom, err := d.readAtom(tag)
+		if err != nil {
+			return nil, err
+		}
+		switch string(atom) {
+		case "nil", "null":
+			return nil, nil
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return etfAtomValue(atom), nil
+
+	case etfBinary:
+		b, err := d.readBinary()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+
+	case etfString:
+		// erlang encodes lists of small integers as strings, e.g. the shard of the ready event
+		n, err := d.readUint16()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]any, len(b))
+		for i, c := range b {
+			items[i] = int64(c)
+		}
+		return items, nil
+
+	case etfNil:
+		return []any{}, nil
+
+	case etfList:
+		n, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		items, err := d.decodeArray(n)
+		if err != nil {
+			return nil, err
+		}
+		// proper lists end with an empty list as tail which we don't need
+		if err = d.readListTail(); err != nil {
+			return nil, err
+		}
+		return items, nil
+
+	case etfSmallTuple:
+		n, err := d.readUint8()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n)
+
+	case etfLargeTuple:
+		n, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n)
+
+	case etfMap:
+		n, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n)
+
+	case etfCompressed:
+		inner, err := d.decompress()
+		if err != nil {
+			return nil, err
+		}
+		return inner.decode()
+
+	default:
+		return nil, fmt.Errorf("unsupported etf tag: %d", tag)
+	}
+}
+
+// readListTail reads the tail of a list, which must be an empty list.
+func (d *etfDecoder) readListTail() error {
+	tail, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	if tail != etfNil {
+		return errors.New("improper etf lists are not supported")
+	}
+	return nil
+}
+
+func (d *etfDecoder) decodeArray(n int) ([]any, error) {
+	items := make([]any, n)
+	for i := 0; i < n; i++ {
+		item, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func (d *etfDecoder) decodeMap(n int) (map[string]any, error) {
+	m := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		key, err := d.readKey()
+		if err != nil {
+			return nil, err
+		}
+		if m[string(key)], err = d.decode(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// etfJSONValue converts the ETF term into the same value json.Unmarshal would produce for an empty interface.
+func etfJSONValue(term any) any {
+	switch term := term.(type) {
+	case int64:
+		return float64(term)
+	case uint64:
+		return float64(term)
+	case etfAtomValue:
+		return string(term)
+	case []any:
+		items := make([]any, len(term))
+		for i, item := range term {
+			items[i] = etfJSONValue(item)
+		}
+		return items
+	case map[string]any:
+		m := make(map[string]any, len(term))
+		for key, value := range term {
+			m[key] = etfJSONValue(value)
+		}
+		return m
+	}
+	return term
+}
+
+// jsonToETF transcodes JSON data into ETF, so messages can be marshalled with their existing JSON implementations.
+// Snowflakes are sent as binaries, which discord accepts in place of integers.
+func jsonToETF(data []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(etfVersion)
+	if err := encodeETF(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeETF(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		writeETFAtom(buf, "nil")
+
+	case bool:
+		writeETFAtom(buf, strconv.FormatBool(v))
+
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > etfMaxSafeInteger {
+			buf.WriteByte(etfNewFloat)
+			_ = binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+			return nil
+		}
+		i := int64(v)
+		switch {
+		case i >= 0 && i <= math.MaxUint8:
+			buf.WriteByte(etfSmallInteger)
+			buf.WriteByte(byte(i))
+		case i >= math.MinInt32 && i <= math.MaxInt32:
+			buf.WriteByte(etfInteger)
+			_ = binary.Write(buf, binary.BigEndian, int32(i))
+		default:
+			var sign byte
+			if i < 0 {
+				sign = 1
+				i = -i
+			}
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], uint64(i))
+			n := 8
+			for n > 0 && b[n-1] == 0 {
+				n--
+			}
+			buf.WriteByte(etfSmallBig)
+			buf.WriteByte(byte(n))
+			buf.WriteByte(sign)
+			buf.Write(b[:n])
+		}
+
+	case string:
+		buf.WriteByte(etfBinary)
+		_ = binary.Write(buf, binary.BigEndian, uint32(len(v)))
+		buf.WriteString(v)
+
+	case []any:
+		if len(v) == 0 {
+			buf.WriteByte(etfNil)
+			return nil
+		}
+		buf.WriteByte(etfList)
+		_ = binary.Write(buf, binary.BigEndian, uint32(len(v)))
+		for _, item := range v {
+			if err := encodeETF(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(etfNil)
+
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte(etfMap)
+		_ = binary.Write(buf, binary.BigEndian, uint32(len(v)))
+		for _, key := range keys {
+			if err := encodeETF(buf, key); err != nil {
+				return err
+			}
+			if err := encodeETF(buf, v[key]); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported etf type: %T", v)
+	}
+	return nil
+}
+
+func writeETFAtom(buf *bytes.Buffer, atom string) {
+	buf.WriteByte(etfSmallAtomUTF8)
+	buf.WriteByte(byte(len(atom)))
+	buf.WriteString(atom)
+}
@@ -0,0 +1,1086 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+	// reflect.Type -> map[string][]int of json field names to field indexes
+	etfFieldCache sync.Map
+	// reflect.Type -> etfDecodeFunc
+	etfDecodeFuncs sync.Map
+	// etfMessageDataKey -> reflect.Type of the MessageData
+	etfMessageDataTypes sync.Map
+
+	// etfNilTerm is used for messages without data
+	etfNilTerm = []byte{etfSmallAtomUTF8, 3, 'n', 'i', 'l'}
+)
+
+type etfMessageDataKey struct {
+	op Opcode
+	t  EventType
+}
+
+// unmarshalETFMessage decodes a gateway Message from ETF data.
+// Message.D is decoded directly from the ETF term. Message.RawD contains its JSON representation if rawD is true.
+func unmarshalETFMessage(data []byte, rawD bool) (Message, error) {
+	d, err := newETFDecoder(data)
+	if err != nil {
+		return Message{}, err
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return Message{}, err
+	}
+	if tag != etfMap {
+		return Message{}, fmt.Errorf("etf gateway message must be a map, got tag %d", tag)
+	}
+	n, err := d.readUint32()
+	if err != nil {
+		return Message{}, err
+	}
+
+	var (
+		message Message
+		dTerm   = &etfDecoder{data: etfNilTerm}
+	)
+	for i := 0; i < n; i++ {
+		key, err := d.readKey()
+		if err != nil {
+			return Message{}, err
+		}
+		switch string(key) {
+		case "op":
+			err = d.unmarshal(&message.Op)
+		case "s":
+			err = d.unmarshal(&message.S)
+		case "t":
+			err = d.unmarshal(&message.T)
+		case "d":
+			// the data is decoded once op & t are known
+			dTerm = &etfDecoder{data: d.data, pos: d.pos}
+			err = d.skip()
+		default:
+			err = d.skip()
+		}
+		if err != nil {
+			return Message{}, err
+		}
+	}
+
+	typ := etfMessageDataType(message.Op, message.T)
+	if rawD {
+		raw := *dTerm
+		var buf bytes.Buffer
+		if err = raw.writeJSON(&buf, typ, nil); err != nil {
+			return Message{}, err
+		}
+		message.RawD = buf.Bytes()
+	}
+	if typ == nil {
+		return message, nil
+	}
+	v := reflect.New(typ).Elem()
+	if err = etfDecodeFuncOf(typ)(dTerm, v); err != nil {
+		return Message{}, fmt.Errorf("failed to decode etf data of op %d %s: %w", message.Op, message.T, err)
+	}
+	message.D = v.Interface().(MessageData)
+	return message, nil
+}
+
+// etfMessageDataType returns the type of MessageData for the given Opcode & EventType or nil if the message has no data.
+func etfMessageDataType(op Opcode, t EventType) reflect.Type {
+	key := etfMessageDataKey{op: op, t: t}
+	if typ, ok := etfMessageDataTypes.Load(key); ok {
+		typ, _ := typ.(reflect.Type)
+		return typ
+	}
+	// unmarshalMessageData returns data of the correct type even if it fails to unmarshal it
+	data, _ := unmarshalMessageData(op, t, []byte("null"))
+	typ := reflect.TypeOf(data)
+	etfMessageDataTypes.Store(key, typ)
+	return typ
+}
+
+// unmarshalETF decodes the ETF data into the value pointed to by v, following the same rules as json.Unmarshal.
+func unmarshalETF(data []byte, v any) error {
+	d, err := newETFDecoder(data)
+	if err != nil {
+		return err
+	}
+	return d.unmarshal(v)
+}
+
+// unmarshal decodes the next term into the value pointed to by v.
+func (d *etfDecoder) unmarshal(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("etf unmarshal target must be a non nil pointer")
+	}
+	return etfDecodeFuncOf(rv.Type().Elem())(d, rv.Elem())
+}
+
+// etfDecodeFunc decodes the next term into v, which is always addressable.
+type etfDecodeFunc func(d *etfDecoder, v reflect.Value) error
+
+// etfDecodeFuncOf returns the etfDecodeFunc of the given type. Like in encoding/json, recursive types get an indirect func while theirs is built.
+func etfDecodeFuncOf(typ reflect.Type) etfDecodeFunc {
+	if f, ok := etfDecodeFuncs.Load(typ); ok {
+		return f.(etfDecodeFunc)
+	}
+
+	var (
+		wg sync.WaitGroup
+		f  etfDecodeFunc
+	)
+	wg.Add(1)
+	indirect, loaded := etfDecodeFuncs.LoadOrStore(typ, etfDecodeFunc(func(d *etfDecoder, v reflect.Value) error {
+		wg.Wait()
+		return f(d, v)
+	}))
+	if loaded {
+		return indirect.(etfDecodeFunc)
+	}
+	f = newETFDecodeFunc(typ)
+	wg.Done()
+	etfDecodeFuncs.Store(typ, f)
+	return f
+}
+
+func newETFDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	if typ.Kind() == reflect.Pointer {
+		return newETFPointerDecodeFunc(typ)
+	}
+	if f := etfTypeDecodeFunc(typ); f != nil {
+		return f
+	}
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) {
+		return decodeETFJSON
+	}
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return decodeETFText
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		if typ.NumMethod() > 0 {
+			return func(d *etfDecoder, v reflect.Value) error {
+				if d.readNil() {
+					v.Set(reflect.Zero(typ))
+					return nil
+				}
+				return fmt.Errorf("cannot unmarshal etf into interface %s", typ)
+			}
+		}
+		return decodeETFAny
+	case reflect.Struct:
+		return newETFStructDecodeFunc(typ)
+	case reflect.Map:
+		return newETFMapDecodeFunc(typ)
+	case reflect.Slice:
+		return newETFSliceDecodeFunc(typ)
+	case reflect.Array:
+		return newETFArrayDecodeFunc(typ)
+	case reflect.String:
+		return decodeETFString
+	case reflect.Bool:
+		return decodeETFBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return decodeETFNumber
+	}
+	return func(_ *etfDecoder, _ reflect.Value) error {
+		return fmt.Errorf("cannot unmarshal etf into %s", typ)
+	}
+}
+
+// etfTypeDecodeFunc returns the etfDecodeFunc of types which implement json.Unmarshaler but are decoded directly instead of being transcoded to JSON.
+// This covers the types of high volume events. The decoders mirror the UnmarshalJSON of their type.
+func etfTypeDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	switch typ {
+	case reflect.TypeOf(snowflake.ID(0)), reflect.TypeOf(discord.Permissions(0)):
+		return decodeETFNumberString
+	case reflect.TypeOf(time.Time{}):
+		return decodeETFText
+	case reflect.TypeOf(discord.Message{}):
+		return decodeETFDiscordMessage
+	case reflect.TypeOf(discord.GatewayGuild{}):
+		return decodeETFGatewayGuild
+	case reflect.TypeOf(EventMessageCreate{}), reflect.TypeOf(EventMessageUpdate{}), reflect.TypeOf(EventGuildCreate{}):
+		return decodeETFEmbedded
+	case reflect.TypeOf(EventInteractionCreate{}):
+		return decodeETFInteraction
+	}
+	return nil
+}
+
+func decodeETFDiscordMessage(d *etfDecoder, v reflect.Value) error {
+	type message discord.Message
+	var m struct {
+		Components []discord.UnmarshalComponent `json:"components"`
+		message
+	}
+	if err := d.unmarshal(&m); err != nil {
+		return err
+	}
+
+	msg := discord.Message(m.message)
+	if len(m.Components) > 0 {
+		msg.Components = make([]discord.ContainerComponent, len(m.Components))
+		for i := range m.Components {
+			component, ok := m.Components[i].Component.(discord.ContainerComponent)
+			if !ok {
+				return fmt.Errorf("component %T is not a container component", m.Components[i].Component)
+			}
+			msg.Components[i] = component
+		}
+	}
+	*v.Addr().Interface().(*discord.Message) = msg
+	return nil
+}
+
+func decodeETFGatewayGuild(d *etfDecoder, v reflect.Value) error {
+	type gatewayGuild discord.GatewayGuild
+	var g struct {
+		Channels []discord.UnmarshalChannel `json:"channels"`
+		gatewayGuild
+	}
+	if err := d.unmarshal(&g); err != nil {
+		return err
+	}
+
+	guild := discord.GatewayGuild(g.gatewayGuild)
+	guild.Channels = make([]discord.GuildChannel, len(g.Channels))
+	for i := range g.Channels {
+		channel, ok := g.Channels[i].Channel.(discord.GuildChannel)
+		if !ok {
+			return fmt.Errorf("channel %T is not a guild channel", g.Channels[i].Channel)
+		}
+		guild.Channels[i] = channel
+	}
+	*v.Addr().Interface().(*discord.GatewayGuild) = guild
+	return nil
+}
+
+// decodeETFInteraction mirrors discord.UnmarshalInteraction. The interaction type is read from ETF, so the JSON of the interaction is only parsed once.
+func decodeETFInteraction(d *etfDecoder, v reflect.Value) error {
+	start := d.pos
+	var iType struct {
+		Type discord.InteractionType `json:"type"`
+	}
+	if err := d.unmarshal(&iType); err != nil {
+		return err
+	}
+	d.pos = start
+
+	var (
+		interaction discord.Interaction
+		err         error
+	)
+	switch iType.Type {
+	case discord.InteractionTypePing:
+		i := discord.PingInteraction{}
+		err = d.unmarshal(&i)
+		interaction = i
+
+	case discord.InteractionTypeApplicationCommand:
+		i := discord.ApplicationCommandInteraction{}
+		err = d.unmarshal(&i)
+		interaction = i
+
+	case discord.InteractionTypeComponent:
+		i := discord.ComponentInteraction{}
+		err = d.unmarshal(&i)
+		interaction = i
+
+	case discord.InteractionTypeAutocomplete:
+		i := discord.AutocompleteInteraction{}
+		err = d.unmarshal(&i)
+		interaction = i
+
+	case discord.InteractionTypeModalSubmit:
+		i := discord.ModalSubmitInteraction{}
+		err = d.unmarshal(&i)
+		interaction = i
+
+	default:
+		err = fmt.Errorf("unknown rawInteraction with type %d received", iType.Type)
+	}
+	if err != nil {
+		return err
+	}
+	v.Addr().Interface().(*EventInteractionCreate).Interaction = interaction
+	return nil
+}
+
+// decodeETFEmbedded decodes structs which only embed a type with its own decoder.
+func decodeETFEmbedded(d *etfDecoder, v reflect.Value) error {
+	return etfDecodeFuncOf(v.Type().Field(0).Type)(d, v.Field(0))
+}
+
+// decodeETFJSON transcodes the term to JSON for types implementing json.Unmarshaler.
+// The JSON is generated using the fields of the type as hints, so snowflakes sent as ETF integers end up as JSON strings.
+func decodeETFJSON(d *etfDecoder, v reflect.Value) error {
+	var buf bytes.Buffer
+	if err := d.writeJSON(&buf, v.Type(), nil); err != nil {
+		return err
+	}
+	return v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(buf.Bytes())
+}
+
+func decodeETFText(d *etfDecoder, v reflect.Value) error {
+	if d.readNil() {
+		return nil
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	var text []byte
+	switch {
+	case tag == etfBinary:
+		text, err = d.readBinary()
+	case isETFAtom(tag):
+		text, err = d.readAtom(tag)
+	case isETFNumber(tag):
+		var n etfNumber
+		n, err = d.readNumber(tag)
+		text = n.append(nil)
+	default:
+		return etfTagError(tag, v.Type())
+	}
+	if err != nil {
+		return err
+	}
+	return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text)
+}
+
+func decodeETFAny(d *etfDecoder, v reflect.Value) error {
+	term, err := d.decode()
+	if err != nil {
+		return err
+	}
+	if term == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	v.Set(reflect.ValueOf(etfJSONValue(term)))
+	return nil
+}
+
+func newETFPointerDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	elem := etfDecodeFuncOf(typ.Elem())
+	return func(d *etfDecoder, v reflect.Value) error {
+		if d.readNil() {
+			v.Set(reflect.Zero(typ))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(typ.Elem()))
+		}
+		return elem(d, v.Elem())
+	}
+}
+
+type etfStructField struct {
+	index  []int
+	decode etfDecodeFunc
+}
+
+func newETFStructDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	fields := map[string]etfStructField{}
+	for name, index := range etfFields(typ) {
+		fields[name] = etfStructField{
+			index:  index,
+			decode: etfDecodeFuncOf(typ.FieldByIndex(index).Type),
+		}
+	}
+
+	return func(d *etfDecoder, v reflect.Value) error {
+		if d.readNil() {
+			return nil
+		}
+		tag, err := d.readUint8()
+		if err != nil {
+			return err
+		}
+		if tag != etfMap {
+			return etfTagError(tag, typ)
+		}
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			key, err := d.readKey()
+			if err != nil {
+				return err
+			}
+			field, ok := fields[string(key)]
+			if !ok {
+				// like json, exact matches are preferred over case-insensitive ones
+				for name, f := range fields {
+					if strings.EqualFold(name, string(key)) {
+						field, ok = f, true
+						break
+					}
+				}
+			}
+			var fieldValue reflect.Value
+			if ok {
+				fieldValue, ok = etfFieldValue(v, field.index)
+			}
+			if !ok {
+				if err = d.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err = field.decode(d, fieldValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func newETFMapDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	elem := etfDecodeFuncOf(typ.Elem())
+	return func(d *etfDecoder, v reflect.Value) error {
+		if d.readNil() {
+			v.Set(reflect.Zero(typ))
+			return nil
+		}
+		tag, err := d.readUint8()
+		if err != nil {
+			return err
+		}
+		if tag != etfMap {
+			return etfTagError(tag, typ)
+		}
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(typ, n))
+		}
+		for i := 0; i < n; i++ {
+			key, err := d.readKey()
+			if err != nil {
+				return err
+			}
+			k := reflect.New(typ.Key()).Elem()
+			if err = assignETFMapKey(string(key), k); err != nil {
+				return err
+			}
+			e := reflect.New(typ.Elem()).Elem()
+			if err = elem(d, e); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+		return nil
+	}
+}
+
+func assignETFMapKey(key string, v reflect.Value) error {
+	switch {
+	case reflect.PointerTo(v.Type()).Implements(textUnmarshalerType):
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key))
+	case v.Kind() == reflect.String:
+		v.SetString(key)
+		return nil
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || v.OverflowInt(i) {
+			return fmt.Errorf("invalid etf map key %q for %s", key, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		u, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || v.OverflowUint(u) {
+			return fmt.Errorf("invalid etf map key %q for %s", key, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	}
+	return fmt.Errorf("unsupported etf map key type %s", v.Type())
+}
+
+// etfListHeader is the header of a list, tuple or string term. Strings are lists of small integers, which are kept in small.
+type etfListHeader struct {
+	n      int
+	small  []byte
+	proper bool
+}
+
+// readList reads the header of a list, tuple or string term.
+func (d *etfDecoder) readList(typ reflect.Type) (etfListHeader, error) {
+	tag, err := d.readUint8()
+	if err != nil {
+		return etfListHeader{}, err
+	}
+	switch tag {
+	case etfNil:
+		return etfListHeader{}, nil
+	case etfList:
+		n, err := d.readUint32()
+		return etfListHeader{n: n, proper: true}, err
+	case etfSmallTuple:
+		n, err := d.readUint8()
+		return etfListHeader{n: n}, err
+	case etfLargeTuple:
+		n, err := d.readUint32()
+		return etfListHeader{n: n}, err
+	case etfString:
+		n, err := d.readUint16()
+		if err != nil {
+			return etfListHeader{}, err
+		}
+		small, err := d.read(n)
+		return etfListHeader{n: n, small: small}, err
+	}
+	return etfListHeader{}, etfTagError(tag, typ)
+}
+
+// decodeItem decodes the item with the given index of the list into v.
+func (d *etfDecoder) decodeItem(list etfListHeader, i int, decode etfDecodeFunc, v reflect.Value) error {
+	if list.small == nil {
+		return decode(d, v)
+	}
+	return decode(&etfDecoder{data: []byte{etfSmallInteger, list.small[i]}}, v)
+}
+
+func newETFSliceDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	elem := etfDecodeFuncOf(typ.Elem())
+	bytesSlice := typ.Elem().Kind() == reflect.Uint8
+	// empty lists are common, so they share one empty slice
+	empty := reflect.MakeSlice(typ, 0, 0)
+	return func(d *etfDecoder, v reflect.Value) error {
+		if d.readNil() {
+			v.Set(reflect.Zero(typ))
+			return nil
+		}
+		if bytesSlice && d.pos < len(d.data) && d.data[d.pos] == etfBinary {
+			// like json, byte slices are base64 encoded strings
+			d.pos++
+			b, err := d.readBinary()
+			if err != nil {
+				return err
+			}
+			decoded := make([]byte, base64.StdEncoding.DecodedLen(len(b)))
+			n, err := base64.StdEncoding.Decode(decoded, b)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(decoded[:n])
+			return nil
+		}
+
+		list, err := d.readList(typ)
+		if err != nil {
+			return err
+		}
+		if list.n == 0 {
+			v.Set(empty)
+			if list.proper {
+				return d.readListTail()
+			}
+			return nil
+		}
+		slice := reflect.MakeSlice(typ, list.n, list.n)
+		for i := 0; i < list.n; i++ {
+			if err = d.decodeItem(list, i, elem, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		if list.proper {
+			if err = d.readListTail(); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+}
+
+func newETFArrayDecodeFunc(typ reflect.Type) etfDecodeFunc {
+	elem := etfDecodeFuncOf(typ.Elem())
+	return func(d *etfDecoder, v reflect.Value) error {
+		if d.readNil() {
+			return nil
+		}
+		list, err := d.readList(typ)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < list.n; i++ {
+			if i >= v.Len() {
+				// like json, additional items are ignored
+				if list.small == nil {
+					if err = d.skip(); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err = d.decodeItem(list, i, elem, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		for i := list.n; i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(typ.Elem()))
+		}
+		if list.proper {
+			return d.readListTail()
+		}
+		return nil
+	}
+}
+
+func decodeETFString(d *etfDecoder, v reflect.Value) error {
+	if d.readNil() {
+		return nil
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	var s []byte
+	switch {
+	case tag == etfBinary:
+		s, err = d.readBinary()
+	case isETFAtom(tag):
+		s, err = d.readAtom(tag)
+	case isETFNumber(tag):
+		var n etfNumber
+		n, err = d.readNumber(tag)
+		s = n.append(nil)
+	default:
+		return etfTagError(tag, v.Type())
+	}
+	if err != nil {
+		return err
+	}
+	v.SetString(string(s))
+	return nil
+}
+
+func decodeETFBool(d *etfDecoder, v reflect.Value) error {
+	if d.readNil() {
+		return nil
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	if !isETFAtom(tag) {
+		return etfTagError(tag, v.Type())
+	}
+	atom, err := d.readAtom(tag)
+	if err != nil {
+		return err
+	}
+	switch string(atom) {
+	case "true":
+		v.SetBool(true)
+	case "false":
+		v.SetBool(false)
+	default:
+		return fmt.Errorf("cannot unmarshal etf atom %s into %s", atom, v.Type())
+	}
+	return nil
+}
+
+func decodeETFNumber(d *etfDecoder, v reflect.Value) error {
+	return d.decodeNumber(v, false)
+}
+
+// decodeETFNumberString decodes numbers which are strings in JSON, like snowflakes & permissions. ETF sends them as integers or binaries.
+func decodeETFNumberString(d *etfDecoder, v reflect.Value) error {
+	return d.decodeNumber(v, true)
+}
+
+func (d *etfDecoder) decodeNumber(v reflect.Value, allowBinary bool) error {
+	if d.readNil() {
+		return nil
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+
+	var n etfNumber
+	switch {
+	case isETFNumber(tag):
+		if n, err = d.readNumber(tag); err != nil {
+			return err
+		}
+	case allowBinary && tag == etfBinary:
+		s, err := d.readBinary()
+		if err != nil {
+			return err
+		}
+		if len(s) == 0 {
+			return nil
+		}
+		if n, err = parseETFNumber(s); err != nil {
+			return fmt.Errorf("cannot unmarshal etf binary %q into %s: %w", s, v.Type(), err)
+		}
+	default:
+		return etfTagError(tag, v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n.isFloat {
+			if n.float != math.Trunc(n.float) || n.float < math.MinInt64 || n.float >= math.MaxInt64 {
+				return etfNumberError(n, v.Type())
+			}
+			n = etfNumber{magnitude: uint64(math.Abs(n.float)), negative: n.float < 0}
+		}
+		if (!n.negative && n.magnitude > math.MaxInt64) || (n.negative && n.magnitude > 1<<63) {
+			return etfNumberError(n, v.Type())
+		}
+		i := int64(n.magnitude)
+		if n.negative {
+			i = -i
+		}
+		if v.OverflowInt(i) {
+			return etfNumberError(n, v.Type())
+		}
+		v.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n.isFloat {
+			if n.float < 0 || n.float != math.Trunc(n.float) || n.float >= math.MaxUint64 {
+				return etfNumberError(n, v.Type())
+			}
+			n = etfNumber{magnitude: uint64(n.float)}
+		}
+		if n.negative || v.OverflowUint(n.magnitude) {
+			return etfNumberError(n, v.Type())
+		}
+		v.SetUint(n.magnitude)
+
+	case reflect.Float32, reflect.Float64:
+		f := n.float
+		if !n.isFloat {
+			f = float64(n.magnitude)
+			if n.negative {
+				f = -f
+			}
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+func parseETFNumber(s []byte) (etfNumber, error) {
+	if u, err := strconv.ParseUint(string(s), 10, 64); err == nil {
+		return etfNumber{magnitude: u}, nil
+	}
+	if len(s) > 1 && s[0] == '-' {
+		if u, err := strconv.ParseUint(string(s[1:]), 10, 64); err == nil {
+			return etfNumber{magnitude: u, negative: true}, nil
+		}
+	}
+	f, err := strconv.ParseFloat(string(s), 64)
+	if err != nil {
+		return etfNumber{}, err
+	}
+	return etfNumber{float: f, isFloat: true}, nil
+}
+
+func etfTagError(tag int, typ reflect.Type) error {
+	return fmt.Errorf("cannot unmarshal etf tag %d into %s", tag, typ)
+}
+
+func etfNumberError(n etfNumber, typ reflect.Type) error {
+	return fmt.Errorf("cannot unmarshal etf number %s into %s", n.append(nil), typ)
+}
+
+// etfFieldValue returns the struct field of v with the given index. Nil embedded struct pointers are allocated.
+func etfFieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+	return v, v.CanSet()
+}
+
+// etfFields returns the json field names of the struct type mapped to their index. Fields of embedded structs are promoted like json does.
+func etfFields(typ reflect.Type) map[string][]int {
+	if fields, ok := etfFieldCache.Load(typ); ok {
+		return fields.(map[string][]int)
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	fields := map[string][]int{}
+	visited := map[reflect.Type]bool{}
+	current := []embedded{{typ: typ}}
+	for len(current) > 0 {
+		var next []embedded
+		depth := map[string]bool{}
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				field := e.typ.Field(i)
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, _, _ := strings.Cut(tag, ",")
+				index := append(append([]int{}, e.index...), i)
+
+				if field.Anonymous && name == "" {
+					fieldType := field.Type
+					if fieldType.Kind() == reflect.Pointer {
+						fieldType = fieldType.Elem()
+					}
+					if fieldType.Kind() == reflect.Struct {
+						next = append(next, embedded{typ: fieldType, index: index})
+						continue
+					}
+				}
+				if !field.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = field.Name
+				}
+				// shallower fields win over deeper ones
+				if _, ok := fields[name]; ok && !depth[name] {
+					continue
+				}
+				fields[name] = index
+				depth[name] = true
+			}
+		}
+		current = next
+	}
+	etfFieldCache.Store(typ, fields)
+	return fields
+}
+
+// writeJSON consumes the next term and writes its JSON representation for the given type, which may be nil if unknown.
+// ETF integers are written as JSON strings where the type expects a string, like snowflakes & permissions.
+func (d *etfDecoder) writeJSON(buf *bytes.Buffer, typ reflect.Type, key []byte) error {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case isETFNumber(tag):
+		n, err := d.readNumber(tag)
+		if err != nil {
+			return err
+		}
+		var b [24]byte
+		if n.isFloat {
+			buf.Write(n.append(b[:0]))
+			return nil
+		}
+		writeETFJSONInteger(buf, n.append(b[:0]), n.magnitude > etfMaxSafeInteger, typ, key)
+
+	case isETFAtom(tag):
+		atom, err := d.readAtom(tag)
+		if err != nil {
+			return err
+		}
+		switch string(atom) {
+		case "nil", "null":
+			buf.WriteString("null")
+		case "true", "false":
+			buf.Write(atom)
+		default:
+			writeJSONString(buf, atom)
+		}
+
+	case tag == etfBinary:
+		b, err := d.readBinary()
+		if err != nil {
+			return err
+		}
+		writeJSONString(buf, b)
+
+	case tag == etfMap:
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			k, err := d.readKey()
+			if err != nil {
+				return err
+			}
+			writeJSONString(buf, k)
+			buf.WriteByte(':')
+			if err = d.writeJSON(buf, etfFieldType(typ, k), k); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+
+	default:
+		d.pos--
+		var elemType reflect.Type
+		if typ != nil && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+			elemType = typ.Elem()
+		}
+		list, err := d.readList(typ)
+		if err != nil {
+			return fmt.Errorf("unsupported etf tag: %d", tag)
+		}
+		buf.WriteByte('[')
+		for i := 0; i < list.n; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if list.small != nil {
+				buf.WriteString(strconv.Itoa(int(list.small[i])))
+				continue
+			}
+			// items of unknown lists inherit the key, so ids in lists like "role_ids" are recognized
+			if err = d.writeJSON(buf, elemType, key); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		if list.proper {
+			return d.readListTail()
+		}
+	}
+	return nil
+}
+
+func writeETFJSONInteger(buf *bytes.Buffer, integer []byte, big bool, typ reflect.Type, key []byte) {
+	var quote bool
+	if typ == nil || typ.Kind() == reflect.Interface {
+		// without a type we can only guess by size & key whether the integer is a snowflake
+		quote = big || string(key) == "id" || bytes.HasSuffix(key, []byte("_id")) || bytes.HasSuffix(key, []byte("_ids"))
+	} else {
+		switch typ.Kind() {
+		case reflect.String:
+			quote = true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// integer types with their own json.Unmarshaler like snowflake.ID or discord.Permissions are strings in JSON
+			quote = reflect.PointerTo(typ).Implements(jsonUnmarshalerType)
+		}
+	}
+	if quote {
+		buf.WriteByte('"')
+		buf.Write(integer)
+		buf.WriteByte('"')
+		return
+	}
+	buf.Write(integer)
+}
+
+// etfFieldType returns the type of the value with the given key in a struct or map type or nil if unknown.
+func etfFieldType(typ reflect.Type, key []byte) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+	switch typ.Kind() {
+	case reflect.Map:
+		return typ.Elem()
+	case reflect.Struct:
+		// discord only sends exact keys, so unlike json there is no case-insensitive match
+		if index, ok := etfFields(typ)[string(key)]; ok {
+			return typ.FieldByIndex(index).Type
+		}
+	}
+	return nil
+}
+
+const hex = "0123456789abcdef"
+
+func writeJSONString(buf *bytes.Buffer, s []byte) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= 0x20 && c != '"' && c != '\\' && c < utf8.RuneSelf {
+			i++
+			continue
+		}
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRune(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf.Write(s[start:i])
+				buf.WriteString(`�`)
+				i++
+				start = i
+				continue
+			}
+			i += size
+			continue
+		}
+		buf.Write(s[start:i])
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[c>>4])
+			buf.WriteByte(hex[c&0xf])
+		}
+		i++
+		start = i
+	}
+	buf.Write(s[start:])
+	buf.WriteByte('"')
+}
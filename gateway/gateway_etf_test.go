@@ -0,0 +1,127 @@
+package gateway
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// The .etf fixtures are encoded the way discord sends them: map keys, event types, nil & bools as atoms,
+// snowflakes as integers of any size and lists of small integers as strings.
+// Every .etf fixture has a .json fixture with the same message for comparison.
+func TestETFConformance(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/etf/*.etf")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			etf, err := os.ReadFile(fixture)
+			assert.NoError(t, err)
+			data, err := os.ReadFile(strings.TrimSuffix(fixture, ".etf") + ".json")
+			assert.NoError(t, err)
+
+			var jsonMessage Message
+			assert.NoError(t, json.Unmarshal(data, &jsonMessage))
+			etfMessage, err := unmarshalETFMessage(etf, true)
+			assert.NoError(t, err)
+
+			assert.Equal(t, jsonMessage.Op, etfMessage.Op)
+			assert.Equal(t, jsonMessage.S, etfMessage.S)
+			assert.Equal(t, jsonMessage.T, etfMessage.T)
+			assert.Equal(t, jsonMessage.D, etfMessage.D)
+
+			// raw events must still receive valid json
+			var rawD any
+			assert.NoError(t, json.Unmarshal(etfMessage.RawD, &rawD))
+
+			// without raw events no json is generated
+			etfMessage, err = unmarshalETFMessage(etf, false)
+			assert.NoError(t, err)
+			assert.Equal(t, jsonMessage.D, etfMessage.D)
+			assert.Nil(t, etfMessage.RawD)
+		})
+	}
+}
+
+func TestETFSmallSnowflakes(t *testing.T) {
+	etf, err := os.ReadFile("testdata/etf/guild_role_delete.etf")
+	assert.NoError(t, err)
+
+	message, err := unmarshalETFMessage(etf, true)
+	assert.NoError(t, err)
+	assert.Equal(t, EventGuildRoleDelete{GuildID: 42, RoleID: 7}, message.D)
+	assert.JSONEq(t, `{"guild_id":"42","role_id":"7"}`, string(message.RawD))
+}
+
+func TestUnmarshalETF(t *testing.T) {
+	var v struct {
+		ID          snowflake.ID        `json:"id"`
+		Permissions discord.Permissions `json:"permissions"`
+		Name        string              `json:"name"`
+		Nullable    *int                `json:"nullable"`
+		Shard       [2]int              `json:"shard"`
+		Extra       map[string]any      `json:"extra"`
+	}
+	// snowflakes & permissions are sent as integers
+	etf, err := jsonToETF([]byte(`{"id":5,"permissions":8,"name":"test","nullable":null,"shard":[1,2],"extra":{"a":"b","c":1}}`))
+	assert.NoError(t, err)
+	assert.NoError(t, unmarshalETF(etf, &v))
+	assert.Equal(t, snowflake.ID(5), v.ID)
+	assert.Equal(t, discord.PermissionAdministrator, v.Permissions)
+	assert.Equal(t, "test", v.Name)
+	assert.Nil(t, v.Nullable)
+	assert.Equal(t, [2]int{1, 2}, v.Shard)
+	assert.Equal(t, map[string]any{"a": "b", "c": float64(1)}, v.Extra)
+
+	etf, err = jsonToETF([]byte(`"test"`))
+	assert.NoError(t, err)
+	assert.Error(t, unmarshalETF(etf, &v.Shard))
+}
+
+func TestJSONToETF(t *testing.T) {
+	data := []byte(`{"op":2,"d":{"token":"abc","intents":3276799,"compress":false,"shard":[0,1],"presence":null,"large_threshold":50.5}}`)
+
+	etf, err := jsonToETF(data)
+	assert.NoError(t, err)
+
+	var v any
+	assert.NoError(t, unmarshalETF(etf, &v))
+	decoded, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(decoded))
+}
+
+func BenchmarkUnmarshalMessage(b *testing.B) {
+	for _, fixture := range []string{"message_create", "guild_create", "interaction_create"} {
+		etf, err := os.ReadFile("testdata/etf/" + fixture + ".etf")
+		assert.NoError(b, err)
+		data, err := os.ReadFile("testdata/etf/" + fixture + ".json")
+		assert.NoError(b, err)
+
+		b.Run(fixture+"/json", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var message Message
+				if err := json.Unmarshal(data, &message); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fixture+"/etf", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := unmarshalETFMessage(etf, false); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -6,7 +6,20 @@ type EventType string
 // Constants for the gateway events
 const (
 	// EventTypeRaw is not a real event type, but is used to pass raw payloads to the bot.EventManager
-	EventTypeRaw                                 EventType = "__RAW__"
+	EventTypeRaw EventType = "__RAW__"
+	// EventTypeHeartbeatAck is not a real event type, but is used to pass heartbeat ACKs to the bot.EventManager
+	EventTypeHeartbeatAck EventType = "__HEARTBEAT_ACK__"
+	// EventTypeZombieConnection is not a real event type, but is used to notify the bot.EventManager about a zombie connection which is being reconnected
+	EventTypeZombieConnection EventType = "__ZOMBIE_CONNECTION__"
+	// EventTypeReconnectAttempt is not a real event type, but is used to notify the bot.EventManager about a reconnect attempt
+	EventTypeReconnectAttempt EventType = "__RECONNECT_ATTEMPT__"
+	// EventTypeReconnectResult is not a real event type, but is used to notify the bot.EventManager about the outcome of a reconnect attempt
+	EventTypeReconnectResult EventType = "__RECONNECT_RESULT__"
+	// EventTypeStatusChange is not a real event type, but is used to pass StatusChange(s) to the bot.EventManager
+	EventTypeStatusChange EventType = "__STATUS_CHANGE__"
+	// EventTypeLatencySample is not a real event type, but is used to periodically pass the Gateway latency to the bot.EventManager
+	EventTypeLatencySample EventType = "__LATENCY_SAMPLE__"
+
 	EventTypeReady                               EventType = "READY"
 	EventTypeResumed                             EventType = "RESUMED"
 	EventTypeApplicationCommandPermissionsUpdate EventType = "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
@@ -135,6 +135,7 @@ func (EventThreadListSync) eventData()   {}
 
 type EventThreadMemberUpdate struct {
 	discord.ThreadMember
+	GuildID snowflake.ID `json:"guild_id"`
 }
 
 func (EventThreadMemberUpdate) messageData() {}
@@ -692,3 +693,57 @@ type EventRaw struct {
 
 func (EventRaw) messageData() {}
 func (EventRaw) eventData()   {}
+
+// EventHeartbeatAck is dispatched when discord acknowledged a heartbeat.
+type EventHeartbeatAck struct {
+	// LastHeartbeat is when the previous heartbeat was acknowledged
+	LastHeartbeat time.Time
+	// NewHeartbeat is when this heartbeat was acknowledged
+	NewHeartbeat time.Time
+}
+
+func (EventHeartbeatAck) messageData() {}
+func (EventHeartbeatAck) eventData()   {}
+
+// EventZombieConnection is dispatched when discord didn't acknowledge the last heartbeat before the next one was due.
+// The connection is closed with a resumable close code & reconnected afterwards.
+type EventZombieConnection struct {
+	LastHeartbeatSent time.Time
+	LastHeartbeatAck  time.Time
+}
+
+func (EventZombieConnection) messageData() {}
+func (EventZombieConnection) eventData()   {}
+
+// EventReconnectAttempt is dispatched before the Gateway tries to reconnect.
+type EventReconnectAttempt struct {
+	// Attempt is the number of the attempt starting at 0
+	Attempt int
+	// Delay is how long the Gateway waits before this attempt
+	Delay time.Duration
+}
+
+func (EventReconnectAttempt) messageData() {}
+func (EventReconnectAttempt) eventData()   {}
+
+// EventReconnectResult is dispatched after a reconnect attempt of the Gateway.
+type EventReconnectResult struct {
+	Attempt int
+	// Err is nil if the Gateway reconnected successfully
+	Err error
+	// GaveUp is true if the Backoff doesn't allow any further attempts
+	GaveUp bool
+}
+
+func (EventReconnectResult) messageData() {}
+func (EventReconnectResult) eventData()   {}
+
+// EventLatencySample is dispatched every Config.LatencySampleInterval while the Gateway is connected.
+type EventLatencySample struct {
+	// Latency is the value of Gateway.Latency at the time of the sample
+	Latency time.Duration
+	Status  Status
+}
+
+func (EventLatencySample) messageData() {}
+func (EventLatencySample) eventData()   {}
@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayZombieConnection(t *testing.T) {
+	connections := make(chan struct{}, 10)
+	// never ACK any heartbeat
+	url := newTestServer(t, 50, func() {
+		connections <- struct{}{}
+	}, nil)
+
+	zombies := make(chan EventZombieConnection, 10)
+	g := New("token", func(eventType EventType, _ int, _ int, event EventData) {
+		if eventType == EventTypeZombieConnection {
+			zombies <- event.(EventZombieConnection)
+		}
+	}, nil, WithURL(url), WithCompress(false))
+	assert.NoError(t, g.Open(context.Background()))
+	defer g.Close(context.Background())
+
+	select {
+	case zombie := <-zombies:
+		assert.False(t, zombie.LastHeartbeatSent.IsZero())
+	case <-time.After(5 * time.Second):
+		t.Fatal("zombie connection not detected")
+	}
+
+	// the gateway reconnects after detecting the zombie connection
+	for i := 0; i < 2; i++ {
+		select {
+		case <-connections:
+		case <-time.After(5 * time.Second):
+			t.Fatal("gateway did not reconnect")
+		}
+	}
+}
@@ -2,11 +2,11 @@ package gateway
 
 import (
 	"bytes"
-	"compress/zlib"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"sync"
 	"syscall"
@@ -25,6 +25,19 @@ func New(token string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc Close
 	config := DefaultConfig()
 	config.Apply(opts)
 
+	if config.SessionStore != nil && config.SessionID == nil {
+		session, err := config.SessionStore.Session(config.ShardID)
+		if err != nil {
+			config.Logger.Errorf("failed to load session of shard %d: %s", config.ShardID, err)
+		} else if session != nil && session.ShardCount == config.ShardCount {
+			config.SessionID = &session.ID
+			config.LastSequenceReceived = &session.Sequence
+			if session.ResumeURL != "" {
+				config.ResumeURL = &session.ResumeURL
+			}
+		}
+	}
+
 	return &gatewayImpl{
 		config:           *config,
 		eventHandlerFunc: eventHandlerFunc,
@@ -40,12 +53,26 @@ type gatewayImpl struct {
 	closeHandlerFunc CloseHandlerFunc
 	token            string
 
-	conn            *websocket.Conn
-	connMu          sync.Mutex
-	heartbeatTicker *time.Ticker
-	status          Status
+	conn         *websocket.Conn
+	connMu       sync.Mutex
+	decompressor decompressor
+
+	status   Status
+	statusMu sync.Mutex
+
+	// sessionMu guards the SessionID, ResumeURL & LastSequenceReceived of the config
+	sessionMu sync.Mutex
 
+	// reconnectCancel cancels the currently running reconnect loop started at reconnectCtx
+	reconnectCancel context.CancelFunc
+	reconnectCtx    context.Context
+	reconnectMu     sync.Mutex
+
+	// heartbeatDone is closed to stop the heartbeat goroutine of the current connection
+	heartbeatDone         chan struct{}
+	heartbeatMu           sync.Mutex
 	heartbeatInterval     time.Duration
+	heartbeatAcked        bool
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
 }
@@ -59,10 +86,20 @@ func (g *gatewayImpl) ShardCount() int {
 }
 
 func (g *gatewayImpl) SessionID() *string {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.SessionID
 }
 
+func (g *gatewayImpl) ResumeURL() *string {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	return g.config.ResumeURL
+}
+
 func (g *gatewayImpl) LastSequenceReceived() *int {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.LastSequenceReceived
 }
 
@@ -85,28 +122,48 @@ func (g *gatewayImpl) formatLogs(a ...any) string {
 }
 
 func (g *gatewayImpl) Open(ctx context.Context) error {
-	return g.reconnectTry(ctx, 0)
+	return g.reconnectTry(ctx, false)
 }
 
 func (g *gatewayImpl) open(ctx context.Context) error {
 	g.config.Logger.Debug(g.formatLogs("opening gateway connection"))
 
+	var (
+		changes      []StatusChange
+		conn         *websocket.Conn
+		decompressor decompressor
+	)
+	// notify about status changes once connMu is released, so the StatusChangeFunc can use the Gateway
+	defer func() {
+		for _, change := range changes {
+			g.notifyStatusChange(change)
+		}
+		if conn != nil {
+			go g.listen(conn, decompressor)
+		}
+	}()
+
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
 	if g.conn != nil {
 		return discord.ErrGatewayAlreadyConnected
 	}
-	g.status = StatusConnecting
+	changes = append(changes, g.updateStatus(StatusConnecting, StatusChange{Reason: StatusChangeReasonConnecting}))
 
 	wsURL := g.config.URL
-	if g.config.ResumeURL != nil && g.config.EnableResumeURL {
-		wsURL = *g.config.ResumeURL
+	if resumeURL := g.ResumeURL(); resumeURL != nil && g.config.EnableResumeURL {
+		wsURL = *resumeURL
+	}
+	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=%s", wsURL, Version, g.config.Encoding)
+	if compress := g.config.TransportCompression.query(); compress != "" {
+		gatewayURL += "&compress=" + compress
 	}
-	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=json", wsURL, Version)
+	g.heartbeatMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
-	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
+	g.heartbeatMu.Unlock()
+	newConn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
 	if err != nil {
-		g.Close(ctx)
+		changes = append(changes, g.updateStatus(StatusDisconnected, StatusChange{Reason: StatusChangeReasonConnectFailed, Err: err}))
 		body := "empty"
 		if rs != nil && rs.Body != nil {
 			defer func() {
@@ -123,18 +180,18 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 		return err
 	}
 
-	conn.SetCloseHandler(func(code int, text string) error {
+	newConn.SetCloseHandler(func(code int, text string) error {
 		return nil
 	})
 
-	g.conn = conn
+	g.conn = newConn
+	g.decompressor = newDecompressor(g.config.TransportCompression)
 
 	// reset rate limiter when connecting
 	g.config.RateLimiter.Reset()
 
-	g.status = StatusWaitingForHello
-
-	go g.listen(conn)
+	changes = append(changes, g.updateStatus(StatusWaitingForHello, StatusChange{Reason: StatusChangeReasonConnected}))
+	conn, decompressor = newConn, g.decompressor
 
 	return nil
 }
@@ -144,11 +201,30 @@ func (g *gatewayImpl) Close(ctx context.Context) {
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
-	if g.heartbeatTicker != nil {
+	// stop any running reconnect as the gateway was closed by the user
+	g.reconnectMu.Lock()
+	if g.reconnectCancel != nil {
+		g.reconnectCancel()
+		g.reconnectCancel = nil
+	}
+	g.reconnectMu.Unlock()
+
+	g.closeWithCode(ctx, code, message)
+	g.setStatus(StatusDisconnected, StatusChange{
+		Reason:    StatusChangeReasonClosed,
+		CloseCode: code,
+		CanResume: g.SessionID() != nil,
+	})
+}
+
+func (g *gatewayImpl) closeWithCode(ctx context.Context, code int, message string) {
+	g.heartbeatMu.Lock()
+	if g.heartbeatDone != nil {
 		g.config.Logger.Debug(g.formatLogs("closing heartbeat goroutines..."))
-		g.heartbeatTicker.Stop()
-		g.heartbeatTicker = nil
+		close(g.heartbeatDone)
+		g.heartbeatDone = nil
 	}
+	g.heartbeatMu.Unlock()
 
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
@@ -163,19 +239,82 @@ func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message strin
 
 		// clear resume data as we closed gracefully
 		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
-			g.config.SessionID = nil
-			g.config.ResumeURL = nil
-			g.config.LastSequenceReceived = nil
+			g.clearSession()
+		}
+		if g.config.SessionStore != nil {
+			if err := g.config.SessionStore.Flush(); err != nil {
+				g.config.Logger.Error(g.formatLogs("failed to flush session store. error: ", err))
+			}
+		}
+	}
+}
+
+// storeSession saves the current session in the SessionStore if there is one.
+func (g *gatewayImpl) storeSession() {
+	if g.config.SessionStore == nil {
+		return
+	}
+	g.sessionMu.Lock()
+	if g.config.SessionID == nil || g.config.LastSequenceReceived == nil {
+		g.sessionMu.Unlock()
+		return
+	}
+	session := Session{
+		ID:         *g.config.SessionID,
+		Sequence:   *g.config.LastSequenceReceived,
+		ShardCount: g.config.ShardCount,
+	}
+	if g.config.ResumeURL != nil {
+		session.ResumeURL = *g.config.ResumeURL
+	}
+	g.sessionMu.Unlock()
+	if err := g.config.SessionStore.SetSession(g.config.ShardID, session); err != nil {
+		g.config.Logger.Error(g.formatLogs("failed to store session. error: ", err))
+	}
+}
+
+// clearSession removes the resume data, so the Gateway identifies on the next connect.
+func (g *gatewayImpl) clearSession() {
+	g.sessionMu.Lock()
+	g.config.SessionID = nil
+	g.config.ResumeURL = nil
+	g.config.LastSequenceReceived = nil
+	g.sessionMu.Unlock()
+	if g.config.SessionStore != nil {
+		if err := g.config.SessionStore.DeleteSession(g.config.ShardID); err != nil {
+			g.config.Logger.Error(g.formatLogs("failed to delete session. error: ", err))
 		}
 	}
 }
 
 func (g *gatewayImpl) Status() Status {
-	g.connMu.Lock()
-	defer g.connMu.Unlock()
+	g.statusMu.Lock()
+	defer g.statusMu.Unlock()
 	return g.status
 }
 
+// updateStatus sets the Status and returns the completed StatusChange without notifying the StatusChangeFunc.
+func (g *gatewayImpl) updateStatus(status Status, change StatusChange) StatusChange {
+	g.statusMu.Lock()
+	defer g.statusMu.Unlock()
+	change.OldStatus = g.status
+	change.NewStatus = status
+	g.status = status
+	return change
+}
+
+func (g *gatewayImpl) notifyStatusChange(change StatusChange) {
+	g.config.Logger.Trace(g.formatLogsf("gateway status changed from %d to %d, reason: %s", change.OldStatus, change.NewStatus, change.Reason))
+	if g.config.StatusChangeFunc != nil {
+		g.config.StatusChangeFunc(g, change)
+	}
+}
+
+// setStatus sets the Status and notifies the StatusChangeFunc. It must not be called while holding connMu.
+func (g *gatewayImpl) setStatus(status Status, change StatusChange) {
+	g.notifyStatusChange(g.updateStatus(status, change))
+}
+
 func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d MessageData) error {
 	data, err := json.Marshal(Message{
 		Op: op,
@@ -184,6 +323,12 @@ func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d MessageData) error
 	if err != nil {
 		return err
 	}
+	if g.config.Encoding == EncodingETF {
+		if data, err = jsonToETF(data); err != nil {
+			return err
+		}
+		return g.send(ctx, websocket.BinaryMessage, data)
+	}
 	return g.send(ctx, websocket.TextMessage, data)
 }
 
@@ -204,6 +349,8 @@ func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) er
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.heartbeatMu.Lock()
+	defer g.heartbeatMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
@@ -211,68 +358,200 @@ func (g *gatewayImpl) Presence() *MessageDataPresenceUpdate {
 	return g.config.Presence
 }
 
-func (g *gatewayImpl) reconnectTry(ctx context.Context, try int) error {
-	delay := time.Duration(try) * 2 * time.Second
-	if delay > 30*time.Second {
-		delay = 30 * time.Second
-	}
+// reconnectTry tries to open the gateway until it succeeds, the Backoff gives up or the context is done.
+// Reconnect events are only dispatched if reconnect is true.
+func (g *gatewayImpl) reconnectTry(ctx context.Context, reconnect bool) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		delay, ok := g.config.Backoff.Delay(attempt)
+		if !ok {
+			return fmt.Errorf("%w after %d attempts: %s", discord.ErrGatewayReconnectGaveUp, attempt, err)
+		}
+		if reconnect {
+			g.setStatus(StatusDisconnected, StatusChange{
+				Reason:  StatusChangeReasonReconnectScheduled,
+				Attempt: attempt,
+				Delay:   delay,
+				Err:     err,
+			})
+			g.eventHandlerFunc(EventTypeReconnectAttempt, 0, g.config.ShardID, EventReconnectAttempt{
+				Attempt: attempt,
+				Delay:   delay,
+			})
+		}
 
-	timer := time.NewTimer(delay)
-	defer timer.Stop()
-	select {
-	case <-ctx.Done():
-		timer.Stop()
-		return ctx.Err()
-	case <-timer.C:
-	}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
 
-	if err := g.open(ctx); err != nil {
-		if err == discord.ErrGatewayAlreadyConnected {
+		if err = g.open(ctx); err == nil || err == discord.ErrGatewayAlreadyConnected {
+			if reconnect {
+				g.eventHandlerFunc(EventTypeReconnectResult, 0, g.config.ShardID, EventReconnectResult{
+					Attempt: attempt,
+					Err:     err,
+				})
+			}
 			return err
 		}
-		g.config.Logger.Error(g.formatLogs("failed to reconnect gateway. error: ", err))
-		g.status = StatusDisconnected
-		return g.reconnectTry(ctx, try+1)
+		g.config.Logger.Error(g.formatLogs("failed to (re)connect gateway. error: ", err))
+
+		if reconnect {
+			_, retry := g.config.Backoff.Delay(attempt + 1)
+			g.eventHandlerFunc(EventTypeReconnectResult, 0, g.config.ShardID, EventReconnectResult{
+				Attempt: attempt,
+				Err:     err,
+				GaveUp:  !retry,
+			})
+		}
 	}
-	return nil
 }
 
 func (g *gatewayImpl) reconnect() {
-	err := g.reconnectTry(context.Background(), 0)
-	if err != nil {
-		g.config.Logger.Error(g.formatLogs("failed to reopen gateway. error: ", err))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g.reconnectMu.Lock()
+	if g.reconnectCancel != nil {
+		g.reconnectCancel()
+	}
+	g.reconnectCancel = cancel
+	g.reconnectCtx = ctx
+	g.reconnectMu.Unlock()
+
+	defer func() {
+		g.reconnectMu.Lock()
+		if g.reconnectCtx == ctx {
+			g.reconnectCancel = nil
+			g.reconnectCtx = nil
+		}
+		g.reconnectMu.Unlock()
+	}()
+
+	err := g.reconnectTry(ctx, true)
+	if err == nil || err == discord.ErrGatewayAlreadyConnected || errors.Is(err, context.Canceled) {
+		return
+	}
+	g.config.Logger.Error(g.formatLogs("failed to reopen gateway. error: ", err))
+	if g.closeHandlerFunc != nil {
+		g.closeHandlerFunc(g, err)
+	}
+}
+
+func (g *gatewayImpl) startHeartbeat(heartbeatInterval time.Duration) {
+	g.heartbeatMu.Lock()
+	defer g.heartbeatMu.Unlock()
+	if g.heartbeatDone != nil {
+		close(g.heartbeatDone)
+	}
+	g.heartbeatDone = make(chan struct{})
+	g.heartbeatInterval = heartbeatInterval
+	g.heartbeatAcked = true
+	g.lastHeartbeatReceived = time.Now().UTC()
+
+	go g.heartbeat(g.heartbeatDone, heartbeatInterval)
+	if g.config.LatencySampleInterval > 0 {
+		go g.sampleLatency(g.heartbeatDone, g.config.LatencySampleInterval)
 	}
 }
 
-func (g *gatewayImpl) heartbeat() {
-	g.heartbeatTicker = time.NewTicker(g.heartbeatInterval)
-	defer g.heartbeatTicker.Stop()
+func (g *gatewayImpl) sampleLatency(done <-chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			g.eventHandlerFunc(EventTypeLatencySample, 0, g.config.ShardID, EventLatencySample{
+				Latency: g.Latency(),
+				Status:  g.Status(),
+			})
+		}
+	}
+}
+
+func (g *gatewayImpl) heartbeat(done <-chan struct{}, heartbeatInterval time.Duration) {
 	defer g.config.Logger.Debug(g.formatLogs("exiting heartbeat goroutine..."))
 
-	for range g.heartbeatTicker.C {
+	// discord wants the first heartbeat to be sent after heartbeatInterval * jitter
+	// See here for more information: https://discord.com/developers/docs/topics/gateway#sending-heartbeats
+	timer := time.NewTimer(time.Duration(rand.Float64() * float64(heartbeatInterval)))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+
+		g.heartbeatMu.Lock()
+		acked, lastSent, lastReceived := g.heartbeatAcked, g.lastHeartbeatSent, g.lastHeartbeatReceived
+		g.heartbeatMu.Unlock()
+
+		if !acked {
+			g.config.Logger.Warn(g.formatLogsf("heartbeat ACK not received since %s, reconnecting zombie connection...", lastSent))
+			g.eventHandlerFunc(EventTypeZombieConnection, 0, g.config.ShardID, EventZombieConnection{
+				LastHeartbeatSent: lastSent,
+				LastHeartbeatAck:  lastReceived,
+			})
+			// use a non 1000 & 1001 close code, so we can resume the session
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			g.closeWithCode(ctx, websocket.CloseServiceRestart, "heartbeat ACK timeout")
+			cancel()
+			g.setStatus(StatusDisconnected, StatusChange{
+				Reason:    StatusChangeReasonZombieConnection,
+				CanResume: g.SessionID() != nil,
+			})
+			go g.reconnect()
+			return
+		}
+
 		g.sendHeartbeat()
+		timer.Reset(heartbeatInterval)
 	}
 }
 
 func (g *gatewayImpl) sendHeartbeat() {
 	g.config.Logger.Debug(g.formatLogs("sending heartbeat..."))
 
-	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	var sequence int
+	if lastSequence := g.LastSequenceReceived(); lastSequence != nil {
+		sequence = *lastSequence
+	}
+
+	// update the heartbeat state before sending, so we don't miss a fast ACK
+	g.heartbeatMu.Lock()
+	heartbeatInterval := g.heartbeatInterval
+	g.heartbeatAcked = false
+	g.lastHeartbeatSent = time.Now().UTC()
+	g.heartbeatMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), heartbeatInterval)
 	defer cancel()
-	if err := g.Send(ctx, OpcodeHeartbeat, MessageDataHeartbeat(*g.config.LastSequenceReceived)); err != nil {
+	if err := g.Send(ctx, OpcodeHeartbeat, MessageDataHeartbeat(sequence)); err != nil {
 		if err == discord.ErrShardNotConnected || errors.Is(err, syscall.EPIPE) {
 			return
 		}
 		g.config.Logger.Error(g.formatLogs("failed to send heartbeat. error: ", err))
-		g.CloseWithCode(context.TODO(), websocket.CloseServiceRestart, "heartbeat timeout")
+		g.closeWithCode(context.TODO(), websocket.CloseServiceRestart, "heartbeat timeout")
+		g.setStatus(StatusDisconnected, StatusChange{
+			Reason:    StatusChangeReasonConnectionError,
+			CanResume: g.SessionID() != nil,
+			Err:       err,
+		})
 		go g.reconnect()
 		return
 	}
-	g.lastHeartbeatSent = time.Now().UTC()
 }
 
-func (g *gatewayImpl) identify() {
-	g.status = StatusIdentifying
+func (g *gatewayImpl) identify() error {
+	g.setStatus(StatusIdentifying, StatusChange{Reason: StatusChangeReasonIdentifying})
 	g.config.Logger.Debug(g.formatLogs("sending Identify command..."))
 
 	identify := MessageDataIdentify{
@@ -282,7 +561,7 @@ func (g *gatewayImpl) identify() {
 			Browser: g.config.Browser,
 			Device:  g.config.Device,
 		},
-		Compress:       g.config.Compress,
+		Compress:       g.config.Compress && g.config.TransportCompression == TransportCompressionNone,
 		LargeThreshold: g.config.LargeThreshold,
 		Intents:        g.config.Intents,
 		Presence:       g.config.Presence,
@@ -293,25 +572,29 @@ func (g *gatewayImpl) identify() {
 
 	if err := g.Send(context.TODO(), OpcodeIdentify, identify); err != nil {
 		g.config.Logger.Error(g.formatLogs("error sending Identify command err: ", err))
+		return err
 	}
-	g.status = StatusWaitingForReady
+	g.setStatus(StatusWaitingForReady, StatusChange{Reason: StatusChangeReasonIdentifySent})
+	return nil
 }
 
-func (g *gatewayImpl) resume() {
-	g.status = StatusResuming
+func (g *gatewayImpl) resume(sessionID string, sequence int) error {
 	resume := MessageDataResume{
 		Token:     g.token,
-		SessionID: *g.config.SessionID,
-		Seq:       *g.config.LastSequenceReceived,
+		SessionID: sessionID,
+		Seq:       sequence,
 	}
 
 	g.config.Logger.Debug(g.formatLogs("sending Resume command..."))
 	if err := g.Send(context.TODO(), OpcodeResume, resume); err != nil {
 		g.config.Logger.Error(g.formatLogs("error sending resume command err: ", err))
+		return err
 	}
+	g.setStatus(StatusResuming, StatusChange{Reason: StatusChangeReasonResumeSent})
+	return nil
 }
 
-func (g *gatewayImpl) listen(conn *websocket.Conn) {
+func (g *gatewayImpl) listen(conn *websocket.Conn, decompressor decompressor) {
 	defer g.config.Logger.Debug(g.formatLogs("exiting listen goroutine..."))
 loop:
 	for {
@@ -327,15 +610,19 @@ loop:
 			}
 
 			reconnect := true
+			change := StatusChange{
+				Reason: StatusChangeReasonConnectionError,
+				Err:    err,
+			}
 			if closeError, ok := err.(*websocket.CloseError); ok {
 				closeCode := CloseEventCodeByCode(closeError.Code)
 				reconnect = closeCode.Reconnect
 
 				if closeCode == CloseEventCodeInvalidSeq {
-					g.config.LastSequenceReceived = nil
-					g.config.SessionID = nil
-					g.config.ResumeURL = nil
+					g.clearSession()
 				}
+				change.Reason = StatusChangeReasonCloseReceived
+				change.CloseCode = closeError.Code
 				message := g.formatLogsf("gateway close received, reconnect: %t, code: %d, error: %s", g.config.AutoReconnect && reconnect, closeError.Code, closeError.Text)
 				if reconnect {
 					g.config.Logger.Debug(message)
@@ -351,8 +638,10 @@ loop:
 
 			// make sure the connection is properly closed
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			g.CloseWithCode(ctx, websocket.CloseServiceRestart, "reconnecting")
+			g.closeWithCode(ctx, websocket.CloseServiceRestart, "reconnecting")
 			cancel()
+			change.CanResume = g.SessionID() != nil
+			g.setStatus(StatusDisconnected, change)
 			if g.config.AutoReconnect && reconnect {
 				go g.reconnect()
 			} else if g.closeHandlerFunc != nil {
@@ -362,7 +651,10 @@ loop:
 			break loop
 		}
 
-		message, err := g.parseMessage(mt, data)
+		message, err := g.parseMessage(decompressor, mt, data)
+		if errors.Is(err, errIncompleteMessage) {
+			continue
+		}
 		if err != nil {
 			g.config.Logger.Error(g.formatLogs("error while parsing gateway message. error: ", err))
 			continue
@@ -370,19 +662,31 @@ loop:
 
 		switch message.Op {
 		case OpcodeHello:
-			g.heartbeatInterval = time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond
-			g.lastHeartbeatReceived = time.Now().UTC()
-			go g.heartbeat()
+			g.startHeartbeat(time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond)
 
-			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
-				g.identify()
+			if sessionID, sequence := g.SessionID(), g.LastSequenceReceived(); sessionID == nil || sequence == nil {
+				err = g.identify()
 			} else {
-				g.resume()
+				err = g.resume(*sessionID, *sequence)
+			}
+			if err != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				g.closeWithCode(ctx, websocket.CloseServiceRestart, "failed to send identify or resume")
+				cancel()
+				g.setStatus(StatusDisconnected, StatusChange{
+					Reason:    StatusChangeReasonSendFailed,
+					Err:       err,
+					CanResume: g.SessionID() != nil,
+				})
+				go g.reconnect()
+				break loop
 			}
 
 		case OpcodeDispatch:
 			// set last sequence received
+			g.sessionMu.Lock()
 			g.config.LastSequenceReceived = &message.S
+			g.sessionMu.Unlock()
 
 			eventData, ok := message.D.(EventData)
 			if !ok && message.D != nil {
@@ -392,12 +696,19 @@ loop:
 
 			// get session id here
 			if readyEvent, ok := eventData.(EventReady); ok {
+				g.sessionMu.Lock()
 				g.config.SessionID = &readyEvent.SessionID
 				g.config.ResumeURL = &readyEvent.ResumeGatewayURL
-				g.status = StatusReady
+				g.sessionMu.Unlock()
+				g.setStatus(StatusReady, StatusChange{Reason: StatusChangeReasonReady})
 				g.config.Logger.Debug(g.formatLogs("ready message received"))
+			} else if message.T == EventTypeResumed {
+				g.setStatus(StatusReady, StatusChange{Reason: StatusChangeReasonResumed})
+				g.config.Logger.Debug(g.formatLogs("resumed message received"))
 			}
 
+			g.storeSession()
+
 			if unknownEvent, ok := eventData.(EventUnknown); ok {
 				g.config.Logger.Debug(g.formatLogsf("unknown event received: %s, data: %s", message.T, unknownEvent))
 				continue
@@ -417,8 +728,12 @@ loop:
 
 		case OpcodeReconnect:
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			g.CloseWithCode(ctx, websocket.CloseServiceRestart, "received reconnect")
+			g.closeWithCode(ctx, websocket.CloseServiceRestart, "received reconnect")
 			cancel()
+			g.setStatus(StatusDisconnected, StatusChange{
+				Reason:    StatusChangeReasonReconnectRequested,
+				CanResume: g.SessionID() != nil,
+			})
 			go g.reconnect()
 			break loop
 
@@ -430,19 +745,31 @@ loop:
 				code = websocket.CloseServiceRestart
 			} else {
 				// clear resume info
-				g.config.SessionID = nil
-				g.config.LastSequenceReceived = nil
-				g.config.ResumeURL = nil
+				g.clearSession()
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			g.CloseWithCode(ctx, code, "invalid session")
+			g.closeWithCode(ctx, code, "invalid session")
 			cancel()
+			g.setStatus(StatusDisconnected, StatusChange{
+				Reason:    StatusChangeReasonInvalidSession,
+				CanResume: bool(canResume),
+			})
 			go g.reconnect()
 			break loop
 
 		case OpcodeHeartbeatACK:
-			g.lastHeartbeatReceived = time.Now().UTC()
+			g.heartbeatMu.Lock()
+			lastHeartbeat := g.lastHeartbeatReceived
+			newHeartbeat := time.Now().UTC()
+			g.heartbeatAcked = true
+			g.lastHeartbeatReceived = newHeartbeat
+			g.heartbeatMu.Unlock()
+
+			g.eventHandlerFunc(EventTypeHeartbeatAck, message.S, g.config.ShardID, EventHeartbeatAck{
+				LastHeartbeat: lastHeartbeat,
+				NewHeartbeat:  newHeartbeat,
+			})
 
 		default:
 			g.config.Logger.Debug(g.formatLogsf("unknown opcode received: %d, data: %s", message.Op, message.D))
@@ -450,19 +777,27 @@ loop:
 	}
 }
 
-func (g *gatewayImpl) parseMessage(mt int, data []byte) (Message, error) {
+func (g *gatewayImpl) parseMessage(decompressor decompressor, mt int, data []byte) (Message, error) {
 	var finalData []byte
 	if mt == websocket.BinaryMessage {
-		g.config.Logger.Trace(g.formatLogs("binary message received. decompressing..."))
+		finalData = data
+		// etf messages are binary even if they are not compressed
+		if g.config.Encoding != EncodingETF || g.config.TransportCompression != TransportCompressionNone || (len(data) > 0 && data[0] != etfVersion) {
+			g.config.Logger.Trace(g.formatLogs("binary message received. decompressing..."))
 
-		reader, err := zlib.NewReader(bytes.NewReader(data))
-		if err != nil {
-			return Message{}, fmt.Errorf("failed to decompress zlib: %w", err)
+			var err error
+			if finalData, err = decompressor.decompress(data); err != nil {
+				return Message{}, err
+			}
 		}
-		defer reader.Close()
-		finalData, err = io.ReadAll(reader)
-		if err != nil {
-			return Message{}, fmt.Errorf("failed to read decompressed data: %w", err)
+		if g.config.Encoding == EncodingETF {
+			// the json representation of the data is only needed for raw events
+			message, err := unmarshalETFMessage(finalData, g.config.EnableRawEvents)
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to decode etf: %w", err)
+			}
+			g.config.Logger.Trace(g.formatLogsf("received etf gateway message: op: %d, s: %d, t: %s, d: %+v", message.Op, message.S, message.T, message.D))
+			return message, nil
 		}
 	} else {
 		finalData = data
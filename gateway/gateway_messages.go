@@ -27,73 +27,80 @@ func (e *Message) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	messageData, err := unmarshalMessageData(v.Op, v.T, v.D)
+	if err != nil {
+		return err
+	}
+	e.Op = v.Op
+	e.S = v.S
+	e.T = v.T
+	e.D = messageData
+	e.RawD = v.D
+	return nil
+}
+
+// unmarshalMessageData unmarshals the data of a Message with the given Opcode & EventType.
+// The returned MessageData has the correct type even if an error is returned.
+func unmarshalMessageData(op Opcode, t EventType, data []byte) (MessageData, error) {
 	var (
 		messageData MessageData
 		err         error
 	)
 
-	switch v.Op {
+	switch op {
 	case OpcodeDispatch:
-		messageData, err = UnmarshalEventData(v.D, v.T)
+		messageData, err = UnmarshalEventData(data, t)
 
 	case OpcodeHeartbeat:
 		var d MessageDataHeartbeat
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeIdentify:
 		var d MessageDataIdentify
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodePresenceUpdate:
 		var d MessageDataPresenceUpdate
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeVoiceStateUpdate:
 		var d MessageDataVoiceStateUpdate
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeResume:
 		var d MessageDataResume
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeReconnect:
 
 	case OpcodeRequestGuildMembers:
 		var d MessageDataRequestGuildMembers
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeInvalidSession:
 		var d MessageDataInvalidSession
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeHello:
 		var d MessageDataHello
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 
 	case OpcodeHeartbeatACK:
 
 	default:
 		var d MessageDataUnknown
-		err = json.Unmarshal(v.D, &d)
+		err = json.Unmarshal(data, &d)
 		messageData = d
 	}
-	if err != nil {
-		return err
-	}
-	e.Op = v.Op
-	e.S = v.S
-	e.T = v.T
-	e.D = messageData
-	e.RawD = v.D
-	return nil
+	return messageData, err
 }
 
 type MessageData interface {
@@ -0,0 +1,195 @@
+package gateway
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+// Session holds everything needed to resume a gateway session instead of identifying again.
+type Session struct {
+	ID         string `json:"id"`
+	ResumeURL  string `json:"resume_url"`
+	Sequence   int    `json:"sequence"`
+	ShardCount int    `json:"shard_count"`
+}
+
+// SessionStore persists the Session of each shard, so a restarted process can resume it within the resume window.
+// The Gateway updates it on every EventTypeReady, dispatch sequence and invalid session. Implementations must be thread safe.
+type SessionStore interface {
+	// Session returns the Session of the given shard or nil if none is stored.
+	Session(shardID int) (*Session, error)
+
+	// SetSession stores the Session of the given shard.
+	SetSession(shardID int, session Session) error
+
+	// DeleteSession removes the Session of the given shard.
+	DeleteSession(shardID int) error
+
+	// Flush persists all pending changes. It is called when a Gateway is closed.
+	Flush() error
+}
+
+var (
+	_ SessionStore = (*memorySessionStore)(nil)
+	_ SessionStore = (*fileSessionStore)(nil)
+)
+
+// NewMemorySessionStore returns a SessionStore which keeps the sessions in memory.
+// This is useful to resume sessions when a Gateway is recreated in the same process.
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		sessions: map[int]Session{},
+	}
+}
+
+type memorySessionStore struct {
+	sessions map[int]Session
+	mu       sync.Mutex
+}
+
+func (s *memorySessionStore) Session(shardID int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[shardID]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *memorySessionStore) SetSession(shardID int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[shardID] = session
+	return nil
+}
+
+func (s *memorySessionStore) DeleteSession(shardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, shardID)
+	return nil
+}
+
+func (s *memorySessionStore) Flush() error {
+	return nil
+}
+
+// NewFileSessionStore returns a SessionStore which persists the sessions as json in the file at the given path.
+// As the sequence changes with every dispatch, writes are delayed by flushInterval and batched.
+// Resuming with a slightly outdated sequence is fine as discord replays all events after it.
+func NewFileSessionStore(path string, flushInterval time.Duration) (SessionStore, error) {
+	s := &fileSessionStore{
+		path:          path,
+		flushInterval: flushInterval,
+		sessions:      map[string]Session{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &s.sessions); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+type fileSessionStore struct {
+	path          string
+	flushInterval time.Duration
+
+	// shard id -> session, the keys are strings to keep the file valid json
+	sessions map[string]Session
+	dirty    bool
+	timer    *time.Timer
+	mu       sync.Mutex
+}
+
+func (s *fileSessionStore) Session(shardID int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[strconv.Itoa(shardID)]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *fileSessionStore) SetSession(shardID int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[strconv.Itoa(shardID)] = session
+	return s.scheduleFlush()
+}
+
+func (s *fileSessionStore) DeleteSession(shardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, strconv.Itoa(shardID))
+	return s.scheduleFlush()
+}
+
+func (s *fileSessionStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flush()
+}
+
+// scheduleFlush marks the sessions as changed and writes them after the flushInterval. s.mu must be held.
+func (s *fileSessionStore) scheduleFlush() error {
+	s.dirty = true
+	if s.flushInterval <= 0 {
+		return s.flush()
+	}
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.flushInterval, func() {
+			_ = s.Flush()
+		})
+	}
+	return nil
+}
+
+// flush writes the sessions to a temporary file and renames it, so the file is never left half written. s.mu must be held.
+func (s *fileSessionStore) flush() error {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if !s.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(s.sessions)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
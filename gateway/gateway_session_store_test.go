@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+
+	store, err := NewFileSessionStore(path, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, store.SetSession(0, Session{ID: "a", ResumeURL: "wss://resume", Sequence: 10, ShardCount: 2}))
+	assert.NoError(t, store.SetSession(1, Session{ID: "b", Sequence: 20, ShardCount: 2}))
+	assert.NoError(t, store.DeleteSession(1))
+	assert.NoError(t, store.Flush())
+
+	store, err = NewFileSessionStore(path, time.Hour)
+	assert.NoError(t, err)
+
+	session, err := store.Session(0)
+	assert.NoError(t, err)
+	assert.Equal(t, &Session{ID: "a", ResumeURL: "wss://resume", Sequence: 10, ShardCount: 2}, session)
+
+	session, err = store.Session(1)
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestGatewayResumesStoredSession(t *testing.T) {
+	messages := make(chan Message, 1)
+	url := newTestServer(t, 45000, nil, func(data []byte) {
+		var message struct {
+			Op Opcode `json:"op"`
+		}
+		if err := json.Unmarshal(data, &message); err == nil && message.Op != OpcodeHeartbeat {
+			messages <- Message{Op: message.Op}
+		}
+	})
+
+	store := NewMemorySessionStore()
+	assert.NoError(t, store.SetSession(0, Session{ID: "session", Sequence: 5, ShardCount: 1}))
+
+	g := New("token", func(EventType, int, int, EventData) {}, nil,
+		WithURL(url),
+		WithCompress(false),
+		WithSessionStore(store),
+	)
+	assert.Equal(t, "session", *g.SessionID())
+	assert.NoError(t, g.Open(context.Background()))
+
+	select {
+	case message := <-messages:
+		assert.Equal(t, OpcodeResume, message.Op)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resume received")
+	}
+
+	// closing without a graceful close code keeps the session
+	g.CloseWithCode(context.Background(), websocket.CloseServiceRestart, "restarting")
+	session, err := store.Session(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "session", session.ID)
+}
@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"time"
+)
+
+// StatusChangeReason describes why the Status of a Gateway changed.
+type StatusChangeReason int
+
+const (
+	// StatusChangeReasonConnecting is used when the Gateway starts dialing discord.
+	StatusChangeReasonConnecting StatusChangeReason = iota
+	// StatusChangeReasonConnectFailed is used when the Gateway failed to dial discord.
+	StatusChangeReasonConnectFailed
+	// StatusChangeReasonConnected is used when the websocket connection was established and the Gateway waits for the OpcodeHello.
+	StatusChangeReasonConnected
+	// StatusChangeReasonIdentifySent is used when the Gateway sent an OpcodeIdentify.
+	StatusChangeReasonIdentifySent
+	// StatusChangeReasonResumeSent is used when the Gateway sent an OpcodeResume.
+	StatusChangeReasonResumeSent
+	// StatusChangeReasonReady is used when the Gateway received the EventTypeReady.
+	StatusChangeReasonReady
+	// StatusChangeReasonResumed is used when the Gateway received the EventTypeResumed.
+	StatusChangeReasonResumed
+	// StatusChangeReasonInvalidSession is used when discord invalidated the session. StatusChange.CanResume tells whether the session can be resumed.
+	StatusChangeReasonInvalidSession
+	// StatusChangeReasonReconnectRequested is used when discord requested a reconnect via OpcodeReconnect.
+	StatusChangeReasonReconnectRequested
+	// StatusChangeReasonCloseReceived is used when discord closed the connection. StatusChange.CloseCode holds the close code.
+	StatusChangeReasonCloseReceived
+	// StatusChangeReasonConnectionError is used when the connection failed with StatusChange.Err.
+	StatusChangeReasonConnectionError
+	// StatusChangeReasonZombieConnection is used when discord didn't acknowledge the last heartbeat in time.
+	StatusChangeReasonZombieConnection
+	// StatusChangeReasonReconnectScheduled is used when the Gateway schedules a reconnect attempt after StatusChange.Delay.
+	StatusChangeReasonReconnectScheduled
+	// StatusChangeReasonClosed is used when the Gateway was closed via Gateway.Close or Gateway.CloseWithCode.
+	StatusChangeReasonClosed
+	// StatusChangeReasonIdentifying is used when the Gateway starts sending an OpcodeIdentify.
+	StatusChangeReasonIdentifying
+	// StatusChangeReasonSendFailed is used when sending an OpcodeIdentify or OpcodeResume failed with StatusChange.Err. The Gateway reconnects afterwards.
+	StatusChangeReasonSendFailed
+)
+
+// String returns a human-readable representation of the StatusChangeReason.
+func (r StatusChangeReason) String() string {
+	switch r {
+	case StatusChangeReasonConnecting:
+		return "connecting"
+	case StatusChangeReasonConnectFailed:
+		return "connect failed"
+	case StatusChangeReasonConnected:
+		return "connected"
+	case StatusChangeReasonIdentifySent:
+		return "identify sent"
+	case StatusChangeReasonResumeSent:
+		return "resume sent"
+	case StatusChangeReasonReady:
+		return "ready"
+	case StatusChangeReasonResumed:
+		return "resumed"
+	case StatusChangeReasonInvalidSession:
+		return "invalid session"
+	case StatusChangeReasonReconnectRequested:
+		return "reconnect requested"
+	case StatusChangeReasonCloseReceived:
+		return "close received"
+	case StatusChangeReasonConnectionError:
+		return "connection error"
+	case StatusChangeReasonZombieConnection:
+		return "zombie connection"
+	case StatusChangeReasonReconnectScheduled:
+		return "reconnect scheduled"
+	case StatusChangeReasonClosed:
+		return "closed"
+	case StatusChangeReasonIdentifying:
+		return "identifying"
+	case StatusChangeReasonSendFailed:
+		return "send failed"
+	default:
+		return "unknown"
+	}
+}
+
+// StatusChange describes a step in the lifecycle of a Gateway connection.
+// OldStatus and NewStatus might be equal, for example when a reconnect is scheduled.
+type StatusChange struct {
+	OldStatus Status
+	NewStatus Status
+	Reason    StatusChangeReason
+
+	// CloseCode is the close code sent by discord or used by Gateway.CloseWithCode. It is 0 if the connection wasn't closed.
+	CloseCode int
+	// CanResume tells whether the session can be resumed after an invalid session or close code.
+	CanResume bool
+	// Attempt is the reconnect attempt starting at 0. It is only set for StatusChangeReasonReconnectScheduled.
+	Attempt int
+	// Delay is how long the Gateway waits before the reconnect attempt. It is only set for StatusChangeReasonReconnectScheduled.
+	Delay time.Duration
+	// Err is the error which caused the change, if any.
+	Err error
+}
+
+func (StatusChange) messageData() {}
+func (StatusChange) eventData()   {}
+
+// StatusChangeFunc is called whenever the Status of a Gateway changes.
+// It is called synchronously from the Gateway goroutines, so it must not block.
+type StatusChangeFunc func(gateway Gateway, change StatusChange)
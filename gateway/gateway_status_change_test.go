@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayStatusChanges(t *testing.T) {
+	url := newTestServer(t, 45000, nil, nil)
+
+	changes := make(chan StatusChange, 10)
+	g := New("token", func(EventType, int, int, EventData) {}, nil,
+		WithURL(url),
+		WithCompress(false),
+		WithStatusChangeFunc(func(_ Gateway, change StatusChange) {
+			changes <- change
+		}),
+	)
+	assert.NoError(t, g.Open(context.Background()))
+
+	expected := []StatusChange{
+		{OldStatus: StatusUnconnected, NewStatus: StatusConnecting, Reason: StatusChangeReasonConnecting},
+		{OldStatus: StatusConnecting, NewStatus: StatusWaitingForHello, Reason: StatusChangeReasonConnected},
+		{OldStatus: StatusWaitingForHello, NewStatus: StatusIdentifying, Reason: StatusChangeReasonIdentifying},
+		{OldStatus: StatusIdentifying, NewStatus: StatusWaitingForReady, Reason: StatusChangeReasonIdentifySent},
+	}
+	for _, e := range expected {
+		select {
+		case change := <-changes:
+			assert.Equal(t, e, change)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("status change %s not received", e.Reason)
+		}
+	}
+
+	g.Close(context.Background())
+	assert.Equal(t, StatusChange{
+		OldStatus: StatusWaitingForReady,
+		NewStatus: StatusDisconnected,
+		Reason:    StatusChangeReasonClosed,
+		CloseCode: websocket.CloseNormalClosure,
+	}, <-changes)
+}
+
+type failingRateLimiter struct {
+	RateLimiter
+	fail chan error
+}
+
+func (l *failingRateLimiter) Wait(ctx context.Context) error {
+	select {
+	case err := <-l.fail:
+		return err
+	default:
+		return l.RateLimiter.Wait(ctx)
+	}
+}
+
+func TestGatewayStatusChangeSendFailed(t *testing.T) {
+	url := newTestServer(t, 45000, nil, nil)
+
+	sendErr := errors.New("send failed")
+	rateLimiter := &failingRateLimiter{RateLimiter: NewRateLimiter(), fail: make(chan error, 1)}
+	rateLimiter.fail <- sendErr
+
+	changes := make(chan StatusChange, 20)
+	g := New("token", func(EventType, int, int, EventData) {}, nil,
+		WithURL(url),
+		WithCompress(false),
+		WithRateLimiter(rateLimiter),
+		WithStatusChangeFunc(func(_ Gateway, change StatusChange) {
+			changes <- change
+		}),
+	)
+	assert.NoError(t, g.Open(context.Background()))
+	defer g.Close(context.Background())
+
+	// the failed identify is reported and the gateway identifies again after reconnecting
+	var failed bool
+	for {
+		select {
+		case change := <-changes:
+			if change.Reason == StatusChangeReasonSendFailed {
+				assert.Equal(t, StatusDisconnected, change.NewStatus)
+				assert.ErrorIs(t, change.Err, sendErr)
+				failed = true
+			}
+			if change.Reason == StatusChangeReasonIdentifySent {
+				assert.True(t, failed)
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("gateway did not identify after the failed identify")
+		}
+	}
+}
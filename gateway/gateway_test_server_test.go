@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestServer starts a fake gateway which sends a hello with the given heartbeat interval on every connection
+// and never acknowledges heartbeats. onConnect & onMessage are optional and called for every connection & received message.
+// It returns the websocket url of the fake gateway.
+func newTestServer(t *testing.T, heartbeatInterval int, onConnect func(), onMessage func(data []byte)) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if onConnect != nil {
+			onConnect()
+		}
+
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"op":10,"d":{"heartbeat_interval":`+strconv.Itoa(heartbeatInterval)+`}}`))
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if onMessage != nil {
+				onMessage(data)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
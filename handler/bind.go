@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	// ErrOptionMissing is returned when a required option is missing.
+	ErrOptionMissing = errors.New("option is missing")
+
+	// ErrOptionInvalid is returned when an option can't be decoded into its field or is not one of the allowed enum values.
+	ErrOptionInvalid = errors.New("option is invalid")
+)
+
+// OptionBindError is returned when an option can't be bound to its struct field.
+// Err is either ErrOptionMissing, ErrOptionInvalid or wraps one of them.
+type OptionBindError struct {
+	Name string
+	Err  error
+}
+
+func (e *OptionBindError) Error() string {
+	return fmt.Sprintf("option %q: %s", e.Name, e.Err)
+}
+
+func (e *OptionBindError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	userType       = reflect.TypeOf(discord.User{})
+	memberType     = reflect.TypeOf(discord.ResolvedMember{})
+	roleType       = reflect.TypeOf(discord.Role{})
+	channelType    = reflect.TypeOf(discord.ResolvedChannel{})
+	attachmentType = reflect.TypeOf(discord.Attachment{})
+)
+
+// BindOptions decodes the options of the discord.SlashCommandInteractionData into the struct v points to.
+//
+// Fields are bound by their `discord:"name"` tag. The tag supports the following flags separated by commas:
+//   - required: returns an OptionBindError with ErrOptionMissing if the option is missing
+//   - enum=a|b|c: returns an OptionBindError with ErrOptionInvalid if the option value is none of the given values
+//
+// Supported field types are strings, bools, integers, floats, snowflake.ID, discord.User, discord.ResolvedMember, discord.Role, discord.ResolvedChannel & discord.Attachment
+// as well as named types of them. Pointer fields are left nil if the option is missing. Embedded structs without a tag are bound recursively.
+func BindOptions(data discord.SlashCommandInteractionData, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("options can only be bound to a struct pointer, got %T", v)
+	}
+	return bindStruct(data, rv.Elem())
+}
+
+// CommandWithOptions returns a CommandHandler which binds the slash command options into a new T before calling the handler.
+// See BindOptions for how options are bound. Binding errors are returned to the Mux without calling the handler.
+func CommandWithOptions[T any](h func(e *CommandEvent, options T) error) CommandHandler {
+	return func(e *CommandEvent) error {
+		var options T
+		if err := e.BindOptions(&options); err != nil {
+			return err
+		}
+		return h(e, options)
+	}
+}
+
+type optionTag struct {
+	name     string
+	required bool
+	enum     []string
+}
+
+func parseOptionTag(tag string) optionTag {
+	parts := strings.Split(tag, ",")
+	optTag := optionTag{name: parts[0]}
+	for _, part := range parts[1:] {
+		switch {
+		case part == "required":
+			optTag.required = true
+		case strings.HasPrefix(part, "enum="):
+			optTag.enum = strings.Split(strings.TrimPrefix(part, "enum="), "|")
+		}
+	}
+	return optTag
+}
+
+func bindStruct(data discord.SlashCommandInteractionData, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("discord")
+		if !ok {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := bindStruct(data, rv.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		if err := bindOption(data, parseOptionTag(tag), rv.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindOption(data discord.SlashCommandInteractionData, tag optionTag, field reflect.Value) error {
+	option, ok := data.Option(tag.name)
+	if !ok {
+		if tag.required {
+			return &OptionBindError{Name: tag.name, Err: ErrOptionMissing}
+		}
+		return nil
+	}
+
+	value := field
+	if field.Kind() == reflect.Ptr {
+		value = reflect.New(field.Type().Elem()).Elem()
+	}
+	if err := decodeOption(data, option, value); err != nil {
+		return &OptionBindError{Name: tag.name, Err: err}
+	}
+
+	if len(tag.enum) > 0 {
+		str := fmt.Sprint(value.Interface())
+		valid := false
+		for _, enumValue := range tag.enum {
+			if str == enumValue {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return &OptionBindError{Name: tag.name, Err: fmt.Errorf("%w: %q is not one of %s", ErrOptionInvalid, str, strings.Join(tag.enum, ", "))}
+		}
+	}
+
+	if field.Kind() == reflect.Ptr {
+		field.Set(value.Addr())
+	}
+	return nil
+}
+
+func decodeOption(data discord.SlashCommandInteractionData, option discord.SlashCommandOption, value reflect.Value) error {
+	var (
+		resolved any
+		err      error
+	)
+	switch value.Type() {
+	case userType:
+		resolved, err = resolveOption(option, data.Resolved.Users)
+	case memberType:
+		resolved, err = resolveOption(option, data.Resolved.Members)
+	case roleType:
+		resolved, err = resolveOption(option, data.Resolved.Roles)
+	case channelType:
+		resolved, err = resolveOption(option, data.Resolved.Channels)
+	case attachmentType:
+		resolved, err = resolveOption(option, data.Resolved.Attachments)
+	default:
+		switch value.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if err = json.Unmarshal(option.Value, value.Addr().Interface()); err != nil {
+				return fmt.Errorf("%w: %s", ErrOptionInvalid, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+	if err != nil {
+		return err
+	}
+	value.Set(reflect.ValueOf(resolved))
+	return nil
+}
+
+func resolveOption[T any](option discord.SlashCommandOption, resolved map[snowflake.ID]T) (T, error) {
+	var (
+		id snowflake.ID
+		v  T
+	)
+	if err := json.Unmarshal(option.Value, &id); err != nil {
+		return v, fmt.Errorf("%w: %s", ErrOptionInvalid, err)
+	}
+	v, ok := resolved[id]
+	if !ok {
+		return v, fmt.Errorf("%w: %s %d is not resolved", ErrOptionInvalid, reflect.TypeOf(v).Name(), id)
+	}
+	return v, nil
+}
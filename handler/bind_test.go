@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type testColor string
+
+type testBaseOptions struct {
+	Ephemeral bool `discord:"ephemeral"`
+}
+
+type testOptions struct {
+	testBaseOptions
+	User       discord.User            `discord:"user,required"`
+	Member     *discord.ResolvedMember `discord:"user"`
+	Role       *discord.Role           `discord:"role"`
+	Channel    discord.ResolvedChannel `discord:"channel"`
+	Attachment *discord.Attachment     `discord:"attachment"`
+	Amount     int                     `discord:"amount"`
+	Color      testColor               `discord:"color,enum=red|green"`
+	Reason     *string                 `discord:"reason"`
+}
+
+func newTestSlashCommandData(t *testing.T, options string) discord.SlashCommandInteractionData {
+	var data discord.SlashCommandInteractionData
+	err := json.Unmarshal([]byte(`{
+		"id": "1",
+		"name": "test",
+		"options": `+options+`,
+		"resolved": {
+			"users": {"10": {"id": "10", "username": "test"}},
+			"members": {"10": {"nick": "nick"}},
+			"channels": {"20": {"id": "20", "name": "general"}}
+		}
+	}`), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestBindOptions(t *testing.T) {
+	data := newTestSlashCommandData(t, `[
+		{"name": "user", "type": 6, "value": "10"},
+		{"name": "channel", "type": 7, "value": "20"},
+		{"name": "amount", "type": 4, "value": 5},
+		{"name": "color", "type": 3, "value": "red"},
+		{"name": "ephemeral", "type": 5, "value": true}
+	]`)
+
+	var options testOptions
+	assert.NoError(t, BindOptions(data, &options))
+	assert.Equal(t, snowflake.ID(10), options.User.ID)
+	if assert.NotNil(t, options.Member) {
+		assert.Equal(t, "nick", *options.Member.Nick)
+	}
+	assert.Nil(t, options.Role)
+	assert.Nil(t, options.Attachment)
+	assert.Nil(t, options.Reason)
+	assert.Equal(t, snowflake.ID(20), options.Channel.ID)
+	assert.Equal(t, 5, options.Amount)
+	assert.Equal(t, testColor("red"), options.Color)
+	assert.True(t, options.Ephemeral)
+}
+
+func TestBindOptionsErrors(t *testing.T) {
+	var bindErr *OptionBindError
+
+	err := BindOptions(newTestSlashCommandData(t, `[]`), &testOptions{})
+	if assert.ErrorAs(t, err, &bindErr) {
+		assert.Equal(t, "user", bindErr.Name)
+	}
+	assert.ErrorIs(t, err, ErrOptionMissing)
+
+	err = BindOptions(newTestSlashCommandData(t, `[
+		{"name": "user", "type": 6, "value": "10"},
+		{"name": "color", "type": 3, "value": "blue"}
+	]`), &testOptions{})
+	if assert.ErrorAs(t, err, &bindErr) {
+		assert.Equal(t, "color", bindErr.Name)
+	}
+	assert.ErrorIs(t, err, ErrOptionInvalid)
+
+	err = BindOptions(newTestSlashCommandData(t, `[
+		{"name": "user", "type": 6, "value": "11"}
+	]`), &testOptions{})
+	assert.ErrorIs(t, err, ErrOptionInvalid)
+}
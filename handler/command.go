@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"fmt"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -40,3 +42,12 @@ func (e *CommandEvent) UpdateFollowupMessage(messageID snowflake.ID, messageUpda
 func (e *CommandEvent) DeleteFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) error {
 	return e.Client().Rest().DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
 }
+
+// BindOptions decodes the slash command options into the struct v points to. See BindOptions for more information.
+func (e *CommandEvent) BindOptions(v any) error {
+	data, ok := e.Data.(discord.SlashCommandInteractionData)
+	if !ok {
+		return fmt.Errorf("options can only be bound for slash commands, got %T", e.Data)
+	}
+	return BindOptions(data, v)
+}
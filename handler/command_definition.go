@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// CommandDefinition is a discord.ApplicationCommandCreate declared via Router.DefineCommand.
+type CommandDefinition struct {
+	Command discord.ApplicationCommandCreate
+	// GuildIDs are the guilds the command is registered in. If empty, the command is registered globally.
+	GuildIDs []snowflake.ID
+}
+
+// DefineCommand declares the given discord.ApplicationCommandCreate, so it can be synced with SyncCommands.
+// If h is not nil, it is registered for the command name. Subcommands can be registered via Route & Command instead.
+// Commands must be defined on the root Router or in a Group, as the pattern of sub-routers can't be part of the command name.
+func (r *Mux) DefineCommand(command discord.ApplicationCommandCreate, h CommandHandler, guildIDs ...snowflake.ID) {
+	if r.pattern != "" {
+		panic("commands must not be defined in a router with a pattern")
+	}
+	r.commands = append(r.commands, CommandDefinition{
+		Command:  command,
+		GuildIDs: guildIDs,
+	})
+	if h != nil {
+		r.Command("/"+command.CommandName(), h)
+	}
+}
+
+// Commands returns all CommandDefinition(s) of the Mux and its sub-routers.
+func (r *Mux) Commands() []CommandDefinition {
+	commands := append([]CommandDefinition{}, r.commands...)
+	for _, route := range r.routes {
+		if mux, ok := route.(*Mux); ok {
+			muxCommands := mux.Commands()
+			if mux.pattern != "" && len(muxCommands) > 0 {
+				panic("commands must not be defined in a router mounted with a pattern")
+			}
+			commands = append(commands, muxCommands...)
+		}
+	}
+	return commands
+}
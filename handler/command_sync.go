@@ -0,0 +1,408 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// DefaultSyncConfig returns a new SyncConfig with all default values.
+func DefaultSyncConfig() *SyncConfig {
+	return &SyncConfig{}
+}
+
+// SyncConfig can be used to configure SyncCommands.
+type SyncConfig struct {
+	DryRun   bool
+	GuildIDs []snowflake.ID
+}
+
+// SyncConfigOpt is a functional option for configuring SyncCommands.
+type SyncConfigOpt func(config *SyncConfig)
+
+// Apply applies the given SyncConfigOpt(s) to the SyncConfig.
+func (c *SyncConfig) Apply(opts []SyncConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithSyncDryRun only computes the SyncAction(s) without applying them.
+func WithSyncDryRun() SyncConfigOpt {
+	return func(config *SyncConfig) {
+		config.DryRun = true
+	}
+}
+
+// WithSyncGuildIDs adds guilds whose commands are synced even if no CommandDefinition uses them.
+// This is useful to delete all commands of guilds which are no longer used.
+func WithSyncGuildIDs(guildIDs ...snowflake.ID) SyncConfigOpt {
+	return func(config *SyncConfig) {
+		config.GuildIDs = append(config.GuildIDs, guildIDs...)
+	}
+}
+
+// SyncActionType is the type of change a SyncAction makes.
+type SyncActionType int
+
+// All SyncActionType(s).
+const (
+	SyncActionTypeCreate SyncActionType = iota
+	SyncActionTypeUpdate
+	SyncActionTypeDelete
+)
+
+func (t SyncActionType) String() string {
+	switch t {
+	case SyncActionTypeCreate:
+		return "create"
+	case SyncActionTypeUpdate:
+		return "update"
+	case SyncActionTypeDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncAction is a single change SyncCommands makes to the registered commands.
+type SyncAction struct {
+	Type SyncActionType
+	// GuildID is nil for global commands.
+	GuildID     *snowflake.ID
+	Name        string
+	CommandType discord.ApplicationCommandType
+	// CommandID is the id of the registered command for SyncActionTypeUpdate & SyncActionTypeDelete.
+	CommandID snowflake.ID
+	// Command is the desired command for SyncActionTypeCreate & SyncActionTypeUpdate.
+	Command discord.ApplicationCommandCreate
+}
+
+func (a SyncAction) String() string {
+	scope := "global"
+	if a.GuildID != nil {
+		scope = "guild " + a.GuildID.String()
+	}
+	return fmt.Sprintf("%s %s command %q (type %d)", a.Type, scope, a.Name, a.CommandType)
+}
+
+// SyncCommands syncs the CommandDefinition(s) of the Mux with the commands registered for the bot.Client. See SyncCommands for more information.
+func (r *Mux) SyncCommands(ctx context.Context, client bot.Client, opts ...SyncConfigOpt) ([]SyncAction, error) {
+	return SyncCommands(ctx, client.Rest(), client.ApplicationID(), r.Commands(), opts...)
+}
+
+// SyncCommands compares the given CommandDefinition(s) with the currently registered global & guild commands
+// and creates, updates or deletes only the commands which changed. It returns the SyncAction(s) it applied or would apply in dry-run mode.
+// Commands are matched by their type & name. Registered commands which are not defined are deleted.
+func SyncCommands(ctx context.Context, applications rest.Applications, applicationID snowflake.ID, commands []CommandDefinition, opts ...SyncConfigOpt) ([]SyncAction, error) {
+	config := DefaultSyncConfig()
+	config.Apply(opts)
+
+	var globalCommands []discord.ApplicationCommandCreate
+	guildCommands := map[snowflake.ID][]discord.ApplicationCommandCreate{}
+	for _, guildID := range config.GuildIDs {
+		guildCommands[guildID] = nil
+	}
+	for _, command := range commands {
+		if len(command.GuildIDs) == 0 {
+			globalCommands = append(globalCommands, command.Command)
+			continue
+		}
+		for _, guildID := range command.GuildIDs {
+			guildCommands[guildID] = append(guildCommands[guildID], command.Command)
+		}
+	}
+
+	var actions []SyncAction
+
+	existing, err := applications.GetGlobalCommands(applicationID, true, rest.WithCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global commands: %w", err)
+	}
+	globalActions, err := planCommandSync(nil, globalCommands, existing)
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, globalActions...)
+
+	guildIDs := make([]snowflake.ID, 0, len(guildCommands))
+	for guildID := range guildCommands {
+		guildIDs = append(guildIDs, guildID)
+	}
+	sort.Slice(guildIDs, func(i, j int) bool {
+		return guildIDs[i] < guildIDs[j]
+	})
+	for _, guildID := range guildIDs {
+		id, desired := guildID, guildCommands[guildID]
+		existing, err = applications.GetGuildCommands(applicationID, id, true, rest.WithCtx(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commands of guild %d: %w", id, err)
+		}
+		guildActions, err := planCommandSync(&id, desired, existing)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, guildActions...)
+	}
+
+	if config.DryRun {
+		return actions, nil
+	}
+	for _, action := range actions {
+		if err = applySyncAction(ctx, applications, applicationID, action); err != nil {
+			return nil, fmt.Errorf("failed to %s: %w", action, err)
+		}
+	}
+	return actions, nil
+}
+
+func applySyncAction(ctx context.Context, applications rest.Applications, applicationID snowflake.ID, action SyncAction) error {
+	var err error
+	switch action.Type {
+	case SyncActionTypeCreate:
+		if action.GuildID == nil {
+			_, err = applications.CreateGlobalCommand(applicationID, action.Command, rest.WithCtx(ctx))
+		} else {
+			_, err = applications.CreateGuildCommand(applicationID, *action.GuildID, action.Command, rest.WithCtx(ctx))
+		}
+
+	case SyncActionTypeUpdate:
+		var commandUpdate discord.ApplicationCommandUpdate
+		if commandUpdate, err = newCommandUpdate(action.Command); err != nil {
+			return err
+		}
+		if action.GuildID == nil {
+			_, err = applications.UpdateGlobalCommand(applicationID, action.CommandID, commandUpdate, rest.WithCtx(ctx))
+		} else {
+			_, err = applications.UpdateGuildCommand(applicationID, *action.GuildID, action.CommandID, commandUpdate, rest.WithCtx(ctx))
+		}
+
+	case SyncActionTypeDelete:
+		if action.GuildID == nil {
+			err = applications.DeleteGlobalCommand(applicationID, action.CommandID, rest.WithCtx(ctx))
+		} else {
+			err = applications.DeleteGuildCommand(applicationID, *action.GuildID, action.CommandID, rest.WithCtx(ctx))
+		}
+	}
+	return err
+}
+
+// newCommandUpdate converts the desired command into an update which replaces all fields of the registered command.
+// Empty options & localizations are sent explicitly, so they are removed from the registered command.
+func newCommandUpdate(command discord.ApplicationCommandCreate) (discord.ApplicationCommandUpdate, error) {
+	switch c := command.(type) {
+	case discord.SlashCommandCreate:
+		options := c.Options
+		if options == nil {
+			options = []discord.ApplicationCommandOption{}
+		}
+		return discord.SlashCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        nonNilLocalizations(c.NameLocalizations),
+			Description:              &c.Description,
+			DescriptionLocalizations: nonNilLocalizations(c.DescriptionLocalizations),
+			Options:                  &options,
+			DefaultMemberPermissions: c.DefaultMemberPermissions,
+			DMPermission:             c.DMPermission,
+			NSFW:                     c.NSFW,
+		}, nil
+
+	case discord.UserCommandCreate:
+		return discord.UserCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        nonNilLocalizations(c.NameLocalizations),
+			DefaultMemberPermissions: c.DefaultMemberPermissions,
+			DMPermission:             c.DMPermission,
+			NSFW:                     c.NSFW,
+		}, nil
+
+	case discord.MessageCommandCreate:
+		return discord.MessageCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        nonNilLocalizations(c.NameLocalizations),
+			DefaultMemberPermissions: c.DefaultMemberPermissions,
+			DMPermission:             c.DMPermission,
+			NSFW:                     c.NSFW,
+		}, nil
+
+	case *discord.SlashCommandCreate:
+		return newCommandUpdate(*c)
+	case *discord.UserCommandCreate:
+		return newCommandUpdate(*c)
+	case *discord.MessageCommandCreate:
+		return newCommandUpdate(*c)
+	}
+	return nil, fmt.Errorf("unsupported command type %T", command)
+}
+
+func nonNilLocalizations(localizations map[discord.Locale]string) *map[discord.Locale]string {
+	if localizations == nil {
+		localizations = map[discord.Locale]string{}
+	}
+	return &localizations
+}
+
+type commandKey struct {
+	t    discord.ApplicationCommandType
+	name string
+}
+
+func planCommandSync(guildID *snowflake.ID, desired []discord.ApplicationCommandCreate, existing []discord.ApplicationCommand) ([]SyncAction, error) {
+	existingCommands := make(map[commandKey]discord.ApplicationCommand, len(existing))
+	for _, command := range existing {
+		existingCommands[commandKey{t: command.Type(), name: command.Name()}] = command
+	}
+
+	var actions []SyncAction
+	for _, command := range desired {
+		key := commandKey{t: command.Type(), name: command.CommandName()}
+		action := SyncAction{
+			GuildID:     guildID,
+			Name:        key.name,
+			CommandType: key.t,
+			Command:     command,
+		}
+
+		existingCommand, ok := existingCommands[key]
+		if !ok {
+			action.Type = SyncActionTypeCreate
+			actions = append(actions, action)
+			continue
+		}
+		delete(existingCommands, key)
+
+		equal, err := commandsEqual(command, existingCommand, guildID != nil)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			action.Type = SyncActionTypeUpdate
+			action.CommandID = existingCommand.ID()
+			actions = append(actions, action)
+		}
+	}
+
+	// keep the order of the registered commands for deletions
+	for _, command := range existing {
+		if _, ok := existingCommands[commandKey{t: command.Type(), name: command.Name()}]; !ok {
+			continue
+		}
+		actions = append(actions, SyncAction{
+			Type:        SyncActionTypeDelete,
+			GuildID:     guildID,
+			Name:        command.Name(),
+			CommandType: command.Type(),
+			CommandID:   command.ID(),
+		})
+	}
+	return actions, nil
+}
+
+// comparableCommand contains all fields of a command which can be set when creating it.
+type comparableCommand struct {
+	Type                     discord.ApplicationCommandType     `json:"type"`
+	Name                     string                             `json:"name"`
+	NameLocalizations        map[discord.Locale]string          `json:"name_localizations,omitempty"`
+	Description              string                             `json:"description,omitempty"`
+	DescriptionLocalizations map[discord.Locale]string          `json:"description_localizations,omitempty"`
+	Options                  []discord.ApplicationCommandOption `json:"options,omitempty"`
+	DefaultMemberPermissions discord.Permissions                `json:"default_member_permissions"`
+	DMPermission             bool                               `json:"dm_permission"`
+	NSFW                     bool                               `json:"nsfw"`
+}
+
+func commandsEqual(desired discord.ApplicationCommandCreate, existing discord.ApplicationCommand, guild bool) (bool, error) {
+	desiredCommand, err := comparableCommandCreate(desired)
+	if err != nil {
+		return false, err
+	}
+	existingCommand := comparableCommand{
+		Type:                     existing.Type(),
+		Name:                     existing.Name(),
+		NameLocalizations:        existing.NameLocalizations(),
+		DefaultMemberPermissions: existing.DefaultMemberPermissions(),
+		DMPermission:             existing.DMPermission(),
+		NSFW:                     existing.NSFW(),
+	}
+	if slashCommand, ok := existing.(discord.SlashCommand); ok {
+		existingCommand.Description = slashCommand.Description
+		existingCommand.DescriptionLocalizations = slashCommand.DescriptionLocalizations
+		existingCommand.Options = slashCommand.Options
+	}
+	// the dm permission is ignored for guild commands
+	if guild {
+		desiredCommand.DMPermission = false
+		existingCommand.DMPermission = false
+	}
+
+	desiredData, err := json.Marshal(desiredCommand)
+	if err != nil {
+		return false, err
+	}
+	existingData, err := json.Marshal(existingCommand)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(desiredData, existingData), nil
+}
+
+func comparableCommandCreate(command discord.ApplicationCommandCreate) (comparableCommand, error) {
+	var (
+		c                        comparableCommand
+		defaultMemberPermissions *json.Nullable[discord.Permissions]
+		dmPermission             *bool
+		nsfw                     *bool
+	)
+	switch cmd := command.(type) {
+	case discord.SlashCommandCreate:
+		c = comparableCommand{
+			Name:                     cmd.Name,
+			NameLocalizations:        cmd.NameLocalizations,
+			Description:              cmd.Description,
+			DescriptionLocalizations: cmd.DescriptionLocalizations,
+			Options:                  cmd.Options,
+		}
+		defaultMemberPermissions, dmPermission, nsfw = cmd.DefaultMemberPermissions, cmd.DMPermission, cmd.NSFW
+
+	case discord.UserCommandCreate:
+		c = comparableCommand{
+			Name:              cmd.Name,
+			NameLocalizations: cmd.NameLocalizations,
+		}
+		defaultMemberPermissions, dmPermission, nsfw = cmd.DefaultMemberPermissions, cmd.DMPermission, cmd.NSFW
+
+	case discord.MessageCommandCreate:
+		c = comparableCommand{
+			Name:              cmd.Name,
+			NameLocalizations: cmd.NameLocalizations,
+		}
+		defaultMemberPermissions, dmPermission, nsfw = cmd.DefaultMemberPermissions, cmd.DMPermission, cmd.NSFW
+
+	case *discord.SlashCommandCreate:
+		return comparableCommandCreate(*cmd)
+	case *discord.UserCommandCreate:
+		return comparableCommandCreate(*cmd)
+	case *discord.MessageCommandCreate:
+		return comparableCommandCreate(*cmd)
+
+	default:
+		return c, fmt.Errorf("unsupported application command create %T", command)
+	}
+	c.Type = command.Type()
+
+	// discord returns 0 for both unset & explicitly 0 default member permissions
+	if defaultMemberPermissions != nil {
+		c.DefaultMemberPermissions = defaultMemberPermissions.Value()
+	}
+	c.DMPermission = dmPermission == nil || *dmPermission
+	c.NSFW = nsfw != nil && *nsfw
+	return c, nil
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type testApplications struct {
+	rest.Applications
+	global  []discord.ApplicationCommand
+	guild   map[snowflake.ID][]discord.ApplicationCommand
+	created []string
+	updated []snowflake.ID
+	deleted []snowflake.ID
+}
+
+func (a *testApplications) GetGlobalCommands(_ snowflake.ID, _ bool, _ ...rest.RequestOpt) ([]discord.ApplicationCommand, error) {
+	return a.global, nil
+}
+
+func (a *testApplications) GetGuildCommands(_ snowflake.ID, guildID snowflake.ID, _ bool, _ ...rest.RequestOpt) ([]discord.ApplicationCommand, error) {
+	return a.guild[guildID], nil
+}
+
+func (a *testApplications) CreateGlobalCommand(_ snowflake.ID, command discord.ApplicationCommandCreate, _ ...rest.RequestOpt) (discord.ApplicationCommand, error) {
+	a.created = append(a.created, command.CommandName())
+	return nil, nil
+}
+
+func (a *testApplications) CreateGuildCommand(_ snowflake.ID, _ snowflake.ID, command discord.ApplicationCommandCreate, _ ...rest.RequestOpt) (discord.ApplicationCommand, error) {
+	a.created = append(a.created, command.CommandName())
+	return nil, nil
+}
+
+func (a *testApplications) UpdateGlobalCommand(_ snowflake.ID, commandID snowflake.ID, _ discord.ApplicationCommandUpdate, _ ...rest.RequestOpt) (discord.ApplicationCommand, error) {
+	a.updated = append(a.updated, commandID)
+	return nil, nil
+}
+
+func (a *testApplications) UpdateGuildCommand(_ snowflake.ID, _ snowflake.ID, commandID snowflake.ID, _ discord.ApplicationCommandUpdate, _ ...rest.RequestOpt) (discord.ApplicationCommand, error) {
+	a.updated = append(a.updated, commandID)
+	return nil, nil
+}
+
+func (a *testApplications) DeleteGlobalCommand(_ snowflake.ID, commandID snowflake.ID, _ ...rest.RequestOpt) error {
+	a.deleted = append(a.deleted, commandID)
+	return nil
+}
+
+func (a *testApplications) DeleteGuildCommand(_ snowflake.ID, _ snowflake.ID, commandID snowflake.ID, _ ...rest.RequestOpt) error {
+	a.deleted = append(a.deleted, commandID)
+	return nil
+}
+
+func newTestApplicationCommands(t *testing.T, data string) []discord.ApplicationCommand {
+	var unmarshalCommands []discord.UnmarshalApplicationCommand
+	if err := json.Unmarshal([]byte(data), &unmarshalCommands); err != nil {
+		t.Fatal(err)
+	}
+	commands := make([]discord.ApplicationCommand, len(unmarshalCommands))
+	for i := range unmarshalCommands {
+		commands[i] = unmarshalCommands[i].ApplicationCommand
+	}
+	return commands
+}
+
+func TestSyncCommands(t *testing.T) {
+	applications := &testApplications{
+		global: newTestApplicationCommands(t, `[
+			{"id": "1", "type": 1, "name": "ping", "description": "ping", "default_member_permissions": null, "dm_permission": true, "nsfw": false},
+			{"id": "2", "type": 1, "name": "echo", "description": "old", "options": [{"type": 3, "name": "text", "description": "text", "required": true}], "default_member_permissions": null, "dm_permission": true},
+			{"id": "3", "type": 1, "name": "old", "description": "old", "default_member_permissions": null, "dm_permission": true}
+		]`),
+	}
+
+	r := New()
+	r.DefineCommand(discord.SlashCommandCreate{Name: "ping", Description: "ping"}, func(e *CommandEvent) error { return nil })
+	r.Group(func(r Router) {
+		r.DefineCommand(discord.SlashCommandCreate{
+			Name:        "echo",
+			Description: "echo",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{Name: "text", Description: "text", Required: true},
+			},
+		}, nil)
+	})
+	r.DefineCommand(discord.UserCommandCreate{Name: "info"}, nil, 100)
+
+	actions, err := SyncCommands(context.Background(), applications, 0, r.Commands(), WithSyncDryRun())
+	assert.NoError(t, err)
+	assert.Empty(t, applications.created)
+	assert.Empty(t, applications.updated)
+	assert.Empty(t, applications.deleted)
+
+	var plan []string
+	for _, action := range actions {
+		plan = append(plan, action.String())
+	}
+	assert.Equal(t, []string{
+		`update global command "echo" (type 1)`,
+		`delete global command "old" (type 1)`,
+		`create guild 100 command "info" (type 2)`,
+	}, plan)
+
+	_, err = SyncCommands(context.Background(), applications, 0, r.Commands())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"info"}, applications.created)
+	assert.Equal(t, []snowflake.ID{2}, applications.updated)
+	assert.Equal(t, []snowflake.ID{3}, applications.deleted)
+}
+
+func TestSyncGuildCommands(t *testing.T) {
+	applications := &testApplications{
+		guild: map[snowflake.ID][]discord.ApplicationCommand{
+			100: newTestApplicationCommands(t, `[
+				{"id": "10", "type": 1, "name": "stale", "description": "stale", "guild_id": "100", "default_member_permissions": null},
+				{"id": "11", "type": 1, "name": "config", "description": "old", "guild_id": "100", "default_member_permissions": null}
+			]`),
+		},
+	}
+
+	r := New()
+	r.DefineCommand(discord.SlashCommandCreate{Name: "config", Description: "config"}, nil, 100)
+
+	actions, err := SyncCommands(context.Background(), applications, 0, r.Commands())
+	assert.NoError(t, err)
+
+	var plan []string
+	for _, action := range actions {
+		plan = append(plan, action.String())
+	}
+	assert.Equal(t, []string{
+		`update guild 100 command "config" (type 1)`,
+		`delete guild 100 command "stale" (type 1)`,
+	}, plan)
+	assert.Empty(t, applications.created)
+	assert.Equal(t, []snowflake.ID{11}, applications.updated)
+	assert.Equal(t, []snowflake.ID{10}, applications.deleted)
+}
+
+func TestSyncCommandPointers(t *testing.T) {
+	applications := &testApplications{
+		global: newTestApplicationCommands(t, `[
+			{"id": "1", "type": 1, "name": "ping", "description": "ping", "default_member_permissions": null, "dm_permission": true},
+			{"id": "2", "type": 2, "name": "info", "default_member_permissions": null, "dm_permission": true},
+			{"id": "3", "type": 3, "name": "report", "default_member_permissions": null, "dm_permission": true}
+		]`),
+	}
+
+	r := New()
+	r.DefineCommand(&discord.SlashCommandCreate{Name: "ping", Description: "ping"}, nil)
+	r.DefineCommand(&discord.UserCommandCreate{Name: "info"}, nil)
+	r.DefineCommand(&discord.MessageCommandCreate{Name: "report"}, nil)
+
+	actions, err := SyncCommands(context.Background(), applications, 0, r.Commands())
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
+func TestDefineCommandWithPattern(t *testing.T) {
+	r := New()
+	assert.Panics(t, func() {
+		r.Route("/admin", func(r Router) {
+			r.DefineCommand(discord.SlashCommandCreate{Name: "ban", Description: "ban"}, nil)
+		})
+	})
+
+	mounted := New()
+	mounted.DefineCommand(discord.SlashCommandCreate{Name: "kick", Description: "kick"}, nil)
+	r.Mount("/admin", mounted)
+	assert.Panics(t, func() { r.Commands() })
+}
+
+func TestNewCommandUpdate(t *testing.T) {
+	commandUpdate, err := newCommandUpdate(discord.SlashCommandCreate{Name: "echo", Description: "echo"})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(commandUpdate)
+	assert.NoError(t, err)
+	// removed options & localizations must be cleared on the registered command
+	assert.JSONEq(t, `{"type": 1, "name": "echo", "name_localizations": {}, "description": "echo", "description_localizations": {}, "options": []}`, string(data))
+}
@@ -7,6 +7,10 @@
 // The handler also supports variables in its path which is especially useful for subcommands, components and modals.
 // Variables are defined by curly braces like {variable} and can be accessed in the handler via the Variables map.
 //
+// Commands can be declared together with their handler via DefineCommand and synced with the registered commands via SyncCommands.
+//
+// Slash command options can be bound to a struct with `discord:"name"` tags via CommandEvent.BindOptions or by registering the handler with CommandWithOptions.
+//
 // You can also register middlewares, which are executed before the handler is called. Middlewares can be used to check permissions, validate input or do other things.
 // Middlewares can also be attached to sub-routers, which is useful if you want to have a middleware for all subcommands of a command as an example.
 // A middleware does not care which interaction type it is, it is just executed before the handler and has the following signature:
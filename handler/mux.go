@@ -27,6 +27,7 @@ type Mux struct {
 	middlewares     []Middleware
 	routes          []Route
 	notFoundHandler NotFoundHandler
+	commands        []CommandDefinition
 }
 
 // OnEvent is called when a new event is received.
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"github.com/disgoorg/snowflake/v2"
+
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
@@ -55,6 +57,10 @@ type Router interface {
 	// Command registers the given CommandHandler to the current Router.
 	Command(pattern string, h CommandHandler)
 
+	// DefineCommand declares the given discord.ApplicationCommandCreate and registers the CommandHandler for it.
+	// Defined commands can be synced with SyncCommands. It panics if the Router has a pattern.
+	DefineCommand(command discord.ApplicationCommandCreate, h CommandHandler, guildIDs ...snowflake.ID)
+
 	// Autocomplete registers the given AutocompleteHandler to the current Router.
 	Autocomplete(pattern string, h AutocompleteHandler)
 
@@ -32,6 +32,12 @@ func GetGatewayHandlers() map[gateway.EventType]bot.GatewayEventHandler {
 
 var allEventHandlers = []bot.GatewayEventHandler{
 	bot.NewGatewayEventHandler(gateway.EventTypeRaw, gatewayHandlerRaw),
+	bot.NewGatewayEventHandler(gateway.EventTypeHeartbeatAck, gatewayHandlerHeartbeatAck),
+	bot.NewGatewayEventHandler(gateway.EventTypeZombieConnection, gatewayHandlerZombieConnection),
+	bot.NewGatewayEventHandler(gateway.EventTypeReconnectAttempt, gatewayHandlerReconnectAttempt),
+	bot.NewGatewayEventHandler(gateway.EventTypeReconnectResult, gatewayHandlerReconnectResult),
+	bot.NewGatewayEventHandler(gateway.EventTypeStatusChange, gatewayHandlerStatusChange),
+	bot.NewGatewayEventHandler(gateway.EventTypeLatencySample, gatewayHandlerLatencySample),
 	bot.NewGatewayEventHandler(gateway.EventTypeReady, gatewayHandlerReady),
 	bot.NewGatewayEventHandler(gateway.EventTypeResumed, gatewayHandlerResumed),
 
@@ -116,8 +116,8 @@ func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID in
 	client.Caches().RemoveVoiceStatesByGuildID(event.ID)
 	client.Caches().RemovePresencesByGuildID(event.ID)
 	// TODO: figure out a better way to remove thread members from cache via guild id without requiring cached GuildThreads
-	client.Caches().ChannelsForEach(func(channel discord.GuildChannel) {
-		if guildThread, ok := channel.(discord.GuildThread); ok && guildThread.GuildID() == event.ID {
+	client.Caches().GuildChannelsForEach(event.ID, func(channel discord.GuildChannel) {
+		if guildThread, ok := channel.(discord.GuildThread); ok {
 			client.Caches().RemoveThreadMembersByThreadID(guildThread.ID())
 		}
 	})
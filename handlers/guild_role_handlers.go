@@ -35,7 +35,10 @@ func gatewayHandlerGuildRoleUpdate(client bot.Client, sequenceNumber int, shardI
 }
 
 func gatewayHandlerGuildRoleDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildRoleDelete) {
-	role, _ := client.Caches().RemoveRole(event.GuildID, event.RoleID)
+	role, ok := client.Caches().RemoveRole(event.GuildID, event.RoleID)
+	if !ok {
+		role, _ = client.Caches().DeletedRole(event.GuildID, event.RoleID)
+	}
 
 	client.EventManager().DispatchEvent(&events.RoleDelete{
 		GenericRole: &events.GenericRole{
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+type testClient struct {
+	bot.Client
+	caches       cache.Caches
+	eventManager bot.EventManager
+	events       []bot.Event
+}
+
+func (c *testClient) Caches() cache.Caches {
+	return c.caches
+}
+
+func (c *testClient) EventManager() bot.EventManager {
+	return c.eventManager
+}
+
+func newTestClient(opts ...cache.ConfigOpt) *testClient {
+	client := &testClient{
+		caches: cache.New(append([]cache.ConfigOpt{cache.WithCaches(cache.FlagsAll), cache.WithTombstoneTTL(time.Minute)}, opts...)...),
+	}
+	client.eventManager = bot.NewEventManager(client, bot.WithListenerFunc(func(e bot.Event) {
+		client.events = append(client.events, e)
+	}))
+	return client
+}
+
+func TestRoleDeleteAfterBulkRemoval(t *testing.T) {
+	client := newTestClient()
+	client.Caches().AddRole(discord.Role{ID: 2, GuildID: 1, Name: "mods"})
+	client.Caches().RemoveRolesByGuildID(1)
+
+	gatewayHandlerGuildRoleDelete(client, 1, 0, gateway.EventGuildRoleDelete{GuildID: 1, RoleID: 2})
+
+	if assert.Len(t, client.events, 1) {
+		event := client.events[0].(*events.RoleDelete)
+		assert.Equal(t, "mods", event.Role.Name)
+	}
+}
+
+func TestMessageDeleteAfterEviction(t *testing.T) {
+	flags := cache.FlagsAll
+	client := newTestClient(cache.WithMessageCache(cache.NewMessageCache(
+		cache.NewBoundedGroupedCache[discord.Message](flags, cache.FlagMessages, nil, nil, cache.WithMaxGroupSize(1)),
+	)))
+	client.Caches().AddMessage(discord.Message{ID: 10, ChannelID: 5, Content: "first"})
+	client.Caches().AddMessage(discord.Message{ID: 11, ChannelID: 5, Content: "second"})
+
+	_, ok := client.Caches().Message(5, 10)
+	assert.False(t, ok)
+
+	gatewayHandlerMessageDelete(client, 1, 0, gateway.EventMessageDelete{ID: 10, ChannelID: 5})
+
+	// the handler also dispatches a DMMessageDelete since the message has no guild
+	if assert.Len(t, client.events, 2) {
+		event := client.events[0].(*events.MessageDelete)
+		assert.Equal(t, "first", event.Message.Content)
+	}
+}
+
+func TestPresenceUpdate(t *testing.T) {
+	client := newTestClient()
+	userID := snowflake.ID(3)
+	client.Caches().AddPresence(discord.Presence{
+		PresenceUser: discord.PresenceUser{ID: userID},
+		GuildID:      1,
+		Status:       discord.OnlineStatusOnline,
+		Activities: []discord.Activity{
+			{ID: "stopped", Name: "a"},
+			{ID: "updated", Name: "b"},
+		},
+	})
+
+	gatewayHandlerPresenceUpdate(client, 1, 0, gateway.EventPresenceUpdate{Presence: discord.Presence{
+		PresenceUser: discord.PresenceUser{ID: userID},
+		GuildID:      1,
+		Status:       discord.OnlineStatusIdle,
+		Activities: []discord.Activity{
+			{ID: "updated", Name: "c"},
+			{ID: "started", Name: "d"},
+		},
+	}})
+
+	var (
+		status  *events.UserStatusUpdate
+		stopped []string
+		started []string
+		updated []string
+	)
+	for _, e := range client.events {
+		switch e := e.(type) {
+		case *events.UserStatusUpdate:
+			status = e
+		case *events.UserActivityStop:
+			stopped = append(stopped, e.Activity.ID)
+		case *events.UserActivityStart:
+			started = append(started, e.Activity.ID)
+		case *events.UserActivityUpdate:
+			updated = append(updated, e.OldActivity.Name+"->"+e.Activity.Name)
+		}
+	}
+	if assert.NotNil(t, status) {
+		assert.Equal(t, discord.OnlineStatusOnline, status.OldStatus)
+		assert.Equal(t, discord.OnlineStatusIdle, status.Status)
+	}
+	assert.Equal(t, []string{"stopped"}, stopped)
+	assert.Equal(t, []string{"started"}, started)
+	assert.Equal(t, []string{"b->c"}, updated)
+
+	presence, ok := client.Caches().Presence(1, userID)
+	if assert.True(t, ok) {
+		assert.Equal(t, discord.OnlineStatusIdle, presence.Status)
+	}
+}
@@ -117,7 +117,10 @@ func gatewayHandlerMessageDeleteBulk(client bot.Client, sequenceNumber int, shar
 func handleMessageDelete(client bot.Client, sequenceNumber int, shardID int, messageID snowflake.ID, channelID snowflake.ID, guildID *snowflake.ID) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
-	message, _ := client.Caches().RemoveMessage(channelID, messageID)
+	message, ok := client.Caches().RemoveMessage(channelID, messageID)
+	if !ok {
+		message, _ = client.Caches().DeletedMessage(channelID, messageID)
+	}
 
 	if channel, ok := client.Caches().GuildThread(channelID); ok {
 		if channel.MessageCount > 0 {
@@ -132,6 +135,7 @@ func handleMessageDelete(client bot.Client, sequenceNumber int, shardID int, mes
 			MessageID:    messageID,
 			Message:      message,
 			ChannelID:    channelID,
+			GuildID:      guildID,
 		},
 	})
 
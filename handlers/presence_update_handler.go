@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"reflect"
+
 	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
 )
 
 func gatewayHandlerPresenceUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventPresenceUpdate) {
-	/*oldPresence := client.Caches().Presences().GetCopy(event.GuildID, event.PresenceUser.ID)
-
-	_ = bot.EntityBuilder.CreatePresence(event, core.CacheStrategyYes)
+	oldPresence, ok := client.Caches().Presence(event.GuildID, event.PresenceUser.ID)
+	client.Caches().AddPresence(event.Presence)
 
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
@@ -17,8 +20,7 @@ func gatewayHandlerPresenceUpdate(client bot.Client, sequenceNumber int, shardID
 		oldClientStatus *discord.ClientStatus
 		oldActivities   []discord.Activity
 	)
-
-	if oldPresence != nil {
+	if ok {
 		oldStatus = oldPresence.Status
 		oldClientStatus = &oldPresence.ClientStatus
 		oldActivities = oldPresence.Activities
@@ -33,7 +35,7 @@ func gatewayHandlerPresenceUpdate(client bot.Client, sequenceNumber int, shardID
 		})
 	}
 
-	if oldClientStatus == nil || oldClientStatus.Desktop != event.ClientStatus.Desktop || oldClientStatus.Mobile != event.ClientStatus.Mobile || oldClientStatus.Web != event.ClientStatus.Web {
+	if oldClientStatus == nil || *oldClientStatus != event.ClientStatus {
 		client.EventManager().DispatchEvent(&events.UserClientStatusUpdate{
 			GenericEvent:    genericEvent,
 			UserID:          event.PresenceUser.ID,
@@ -42,58 +44,45 @@ func gatewayHandlerPresenceUpdate(client bot.Client, sequenceNumber int, shardID
 		})
 	}
 
-	genericUserActivity := events.GenericUserActivity{
-		GenericEvent: genericEvent,
-		UserID:       event.PresenceUser.ID,
-		GuildID:      event.GuildID,
+	newGenericUserActivity := func(activity discord.Activity) *events.GenericUserActivity {
+		return &events.GenericUserActivity{
+			GenericEvent: genericEvent,
+			UserID:       event.PresenceUser.ID,
+			GuildID:      event.GuildID,
+			Activity:     activity,
+		}
 	}
 
 	for _, oldActivity := range oldActivities {
-		var found bool
-		for _, newActivity := range event.Activities {
-			if oldActivity.ID == newActivity.ID {
-				found = true
-				break
-			}
-		}
-		if !found {
-			genericUserActivityEvent.Activity = oldActivity
+		if _, ok := findActivity(event.Activities, oldActivity.ID); !ok {
 			client.EventManager().DispatchEvent(&events.UserActivityStop{
-				GenericUserActivity: &genericUserActivityEvent,
+				GenericUserActivity: newGenericUserActivity(oldActivity),
 			})
 		}
 	}
 
 	for _, newActivity := range event.Activities {
-		var found bool
-		for _, oldActivity := range oldActivities {
-			if newActivity.ID == oldActivity.ID {
-				found = true
-				break
-			}
-		}
-		if !found {
-			genericUserActivityEvent.Activity = newActivity
+		oldActivity, ok := findActivity(oldActivities, newActivity.ID)
+		if !ok {
 			client.EventManager().DispatchEvent(&events.UserActivityStart{
-				GenericUserActivity: &genericUserActivityEvent,
+				GenericUserActivity: newGenericUserActivity(newActivity),
 			})
+			continue
 		}
-	}
-
-	for _, newActivity := range event.Activities {
-		var oldActivity *discord.Activity
-		for _, activity := range oldActivities {
-			if newActivity.ID == activity.ID {
-				oldActivity = &activity
-				break
-			}
-		}
-		if oldActivity != nil && !cmp.Equal(*oldActivity, newActivity) {
-			genericUserActivityEvent.Activity = newActivity
+		if !reflect.DeepEqual(oldActivity, newActivity) {
 			client.EventManager().DispatchEvent(&events.UserActivityUpdate{
-				GenericUserActivity: &genericUserActivityEvent,
-				OldActivity:              *oldActivity,
+				GenericUserActivity: newGenericUserActivity(newActivity),
+				OldActivity:         oldActivity,
 			})
 		}
-	}*/
+	}
+}
+
+func findActivity(activities []discord.Activity, id string) (discord.Activity, bool) {
+	for _, activity := range activities {
+		if activity.ID == id {
+			return activity, true
+		}
+	}
+	return discord.Activity{}, false
 }
@@ -13,6 +13,75 @@ func gatewayHandlerRaw(client bot.Client, sequenceNumber int, shardID int, event
 	})
 }
 
+func gatewayHandlerHeartbeatAck(client bot.Client, sequenceNumber int, shardID int, event gateway.EventHeartbeatAck) {
+	client.EventManager().DispatchEvent(&events.HeartbeatAck{
+		GenericEvent:      events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventHeartbeatAck: event,
+	})
+}
+
+func gatewayHandlerZombieConnection(client bot.Client, sequenceNumber int, shardID int, event gateway.EventZombieConnection) {
+	client.EventManager().DispatchEvent(&events.ZombieConnection{
+		GenericEvent:          events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventZombieConnection: event,
+	})
+}
+
+func gatewayHandlerReconnectAttempt(client bot.Client, sequenceNumber int, shardID int, event gateway.EventReconnectAttempt) {
+	client.EventManager().DispatchEvent(&events.ReconnectAttempt{
+		GenericEvent:          events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventReconnectAttempt: event,
+	})
+}
+
+func gatewayHandlerReconnectResult(client bot.Client, sequenceNumber int, shardID int, event gateway.EventReconnectResult) {
+	client.EventManager().DispatchEvent(&events.ReconnectResult{
+		GenericEvent:         events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventReconnectResult: event,
+	})
+}
+
+func gatewayHandlerStatusChange(client bot.Client, sequenceNumber int, shardID int, event gateway.StatusChange) {
+	genericEvent := &events.GatewayStatusChange{
+		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
+		StatusChange: event,
+	}
+	client.EventManager().DispatchEvent(genericEvent)
+
+	switch event.Reason {
+	case gateway.StatusChangeReasonConnecting:
+		client.EventManager().DispatchEvent(&events.GatewayConnecting{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonConnectFailed:
+		client.EventManager().DispatchEvent(&events.GatewayConnectFailed{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonConnected:
+		client.EventManager().DispatchEvent(&events.GatewayConnected{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonIdentifySent:
+		client.EventManager().DispatchEvent(&events.GatewayIdentifySent{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonResumeSent:
+		client.EventManager().DispatchEvent(&events.GatewayResumeSent{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonInvalidSession:
+		client.EventManager().DispatchEvent(&events.GatewayInvalidSession{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonReconnectRequested, gateway.StatusChangeReasonCloseReceived, gateway.StatusChangeReasonConnectionError, gateway.StatusChangeReasonZombieConnection, gateway.StatusChangeReasonClosed:
+		client.EventManager().DispatchEvent(&events.GatewayDisconnected{GatewayStatusChange: genericEvent})
+
+	case gateway.StatusChangeReasonReconnectScheduled:
+		client.EventManager().DispatchEvent(&events.GatewayReconnectScheduled{GatewayStatusChange: genericEvent})
+	}
+}
+
+func gatewayHandlerLatencySample(client bot.Client, sequenceNumber int, shardID int, event gateway.EventLatencySample) {
+	client.EventManager().DispatchEvent(&events.GatewayLatencySample{
+		GenericEvent:       events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventLatencySample: event,
+	})
+}
+
 func gatewayHandlerReady(client bot.Client, sequenceNumber int, shardID int, event gateway.EventReady) {
 	client.Caches().SetSelfUser(event.User)
 
@@ -39,8 +39,12 @@ func gatewayHandlerThreadUpdate(client bot.Client, sequenceNumber int, shardID i
 }
 
 func gatewayHandlerThreadDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventThreadDelete) {
+	channel, ok := client.Caches().RemoveChannel(event.ID)
+	if !ok {
+		channel, ok = client.Caches().DeletedChannel(event.ID)
+	}
 	var thread discord.GuildThread
-	if channel, ok := client.Caches().RemoveChannel(event.ID); ok {
+	if ok {
 		thread, _ = channel.(discord.GuildThread)
 	}
 	client.Caches().RemoveThreadMembersByThreadID(event.ID)
@@ -70,8 +74,20 @@ func gatewayHandlerThreadListSync(client bot.Client, sequenceNumber int, shardID
 	}
 }
 
-func gatewayHandlerThreadMemberUpdate(_ bot.Client, _ int, _ int, _ gateway.EventData) {
-	// ThreadMembersUpdate kinda handles this already?
+func gatewayHandlerThreadMemberUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventThreadMemberUpdate) {
+	oldThreadMember, _ := client.Caches().ThreadMember(event.ThreadID, event.UserID)
+	client.Caches().AddThreadMember(event.ThreadMember)
+
+	client.EventManager().DispatchEvent(&events.ThreadMemberUpdate{
+		GenericThreadMember: &events.GenericThreadMember{
+			GenericEvent:   events.NewGenericEvent(client, sequenceNumber, shardID),
+			GuildID:        event.GuildID,
+			ThreadID:       event.ThreadID,
+			ThreadMemberID: event.UserID,
+			ThreadMember:   event.ThreadMember,
+		},
+		OldThreadMember: oldThreadMember,
+	})
 }
 
 func gatewayHandlerThreadMembersUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventThreadMembersUpdate) {
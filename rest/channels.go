@@ -30,6 +30,7 @@ type Channels interface {
 	GetMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	GetMessages(channelID snowflake.ID, around snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Message, error)
 	GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Message]
+	GetMessagesPaginator(channelID snowflake.ID, direction PageDirection, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.Message]
 	CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (*discord.Message, error)
 	UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (*discord.Message, error)
 	DeleteMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
@@ -37,6 +38,7 @@ type Channels interface {
 	CrosspostMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
 	GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) ([]discord.User, error)
+	GetReactionsPaginator(channelID snowflake.ID, messageID snowflake.ID, emoji string, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.User]
 	AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveOwnReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveUserReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, userID snowflake.ID, opts ...RequestOpt) error
@@ -142,6 +144,14 @@ func (s *channelImpl) GetMessagesPage(channelID snowflake.ID, startID snowflake.
 	}
 }
 
+func (s *channelImpl) GetMessagesPaginator(channelID snowflake.ID, direction PageDirection, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.Message] {
+	return newIDPaginator(func(before snowflake.ID, after snowflake.ID) ([]discord.Message, error) {
+		return s.GetMessages(channelID, 0, before, after, limit, opts...)
+	}, func(msg discord.Message) snowflake.ID {
+		return msg.ID
+	}, direction, startID, limit)
+}
+
 func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (message *discord.Message, err error) {
 	body, err := messageCreate.ToBody()
 	if err != nil {
@@ -174,10 +184,29 @@ func (s *channelImpl) CrosspostMessage(channelID snowflake.ID, messageID snowfla
 }
 
 func (s *channelImpl) GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) (users []discord.User, err error) {
-	err = s.client.Do(GetReactions.Compile(nil, channelID, messageID, emoji), nil, &users, opts...)
+	return s.getReactions(channelID, messageID, emoji, nil, opts...)
+}
+
+func (s *channelImpl) getReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, queryValues discord.QueryValues, opts ...RequestOpt) (users []discord.User, err error) {
+	err = s.client.Do(GetReactions.Compile(queryValues, channelID, messageID, emoji), nil, &users, opts...)
 	return
 }
 
+// GetReactionsPaginator only supports PageDirectionAfter as discord doesn't support fetching reactions before a user.
+func (s *channelImpl) GetReactionsPaginator(channelID snowflake.ID, messageID snowflake.ID, emoji string, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.User] {
+	return newIDPaginator(func(_ snowflake.ID, after snowflake.ID) ([]discord.User, error) {
+		queryValues := discord.QueryValues{
+			"after": after,
+		}
+		if limit != 0 {
+			queryValues["limit"] = limit
+		}
+		return s.getReactions(channelID, messageID, emoji, queryValues, opts...)
+	}, func(user discord.User) snowflake.ID {
+		return user.ID
+	}, PageDirectionAfter, startID, limit)
+}
+
 func (s *channelImpl) AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error {
 	return s.client.Do(AddReaction.Compile(nil, channelID, messageID, emoji), nil, nil, opts...)
 }
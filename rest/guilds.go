@@ -36,6 +36,7 @@ type Guilds interface {
 
 	GetBans(guildID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Ban, error)
 	GetBansPage(guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Ban]
+	GetBansPaginator(guildID snowflake.ID, direction PageDirection, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.Ban]
 	GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Ban, error)
 	AddBan(guildID snowflake.ID, userID snowflake.ID, deleteMessageDuration time.Duration, opts ...RequestOpt) error
 	DeleteBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -52,6 +53,7 @@ type Guilds interface {
 
 	GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) (*discord.AuditLog, error)
 	GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, limit int, opts ...RequestOpt) AuditLogPage
+	GetAuditLogPaginator(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, direction PageDirection, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.AuditLogEntry]
 
 	GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
 	UpdateGuildWelcomeScreen(guildID snowflake.ID, screenUpdate discord.GuildWelcomeScreenUpdate, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
@@ -193,6 +195,14 @@ func (s *guildImpl) GetBansPage(guildID snowflake.ID, startID snowflake.ID, limi
 	}
 }
 
+func (s *guildImpl) GetBansPaginator(guildID snowflake.ID, direction PageDirection, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.Ban] {
+	return newIDPaginator(func(before snowflake.ID, after snowflake.ID) ([]discord.Ban, error) {
+		return s.GetBans(guildID, before, after, limit, opts...)
+	}, func(ban discord.Ban) snowflake.ID {
+		return ban.User.ID
+	}, direction, startID, limit)
+}
+
 func (s *guildImpl) GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (ban *discord.Ban, err error) {
 	err = s.client.Do(GetBan.Compile(nil, guildID, userID), nil, &ban, opts...)
 	return
@@ -281,6 +291,18 @@ func (s *guildImpl) GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, a
 	}
 }
 
+func (s *guildImpl) GetAuditLogPaginator(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, direction PageDirection, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.AuditLogEntry] {
+	return newIDPaginator(func(before snowflake.ID, after snowflake.ID) ([]discord.AuditLogEntry, error) {
+		log, err := s.GetAuditLog(guildID, userID, actionType, before, after, limit, opts...)
+		if err != nil || log == nil {
+			return nil, err
+		}
+		return log.AuditLogEntries, nil
+	}, func(entry discord.AuditLogEntry) snowflake.ID {
+		return entry.ID
+	}, direction, startID, limit)
+}
+
 func (s *guildImpl) GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (welcomeScreen *discord.GuildWelcomeScreen, err error) {
 	err = s.client.Do(GetGuildWelcomeScreen.Compile(nil, guildID), nil, &welcomeScreen, opts...)
 	return
@@ -15,6 +15,7 @@ func NewMembers(client Client) Members {
 type Members interface {
 	GetMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Member, error)
 	GetMembers(guildID snowflake.ID, limit int, after snowflake.ID, opts ...RequestOpt) ([]discord.Member, error)
+	GetMembersPaginator(guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.Member]
 	SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) ([]discord.Member, error)
 	AddMember(guildID snowflake.ID, userID snowflake.ID, memberAdd discord.MemberAdd, opts ...RequestOpt) (*discord.Member, error)
 	RemoveMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -55,6 +56,15 @@ func (s *memberImpl) GetMembers(guildID snowflake.ID, limit int, after snowflake
 	return
 }
 
+// GetMembersPaginator only supports PageDirectionAfter as discord doesn't support fetching members before a user.
+func (s *memberImpl) GetMembersPaginator(guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.Member] {
+	return newIDPaginator(func(_ snowflake.ID, after snowflake.ID) ([]discord.Member, error) {
+		return s.GetMembers(guildID, limit, after, opts...)
+	}, func(member discord.Member) snowflake.ID {
+		return member.User.ID
+	}, PageDirectionAfter, startID, limit)
+}
+
 func (s *memberImpl) SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) (members []discord.Member, err error) {
 	values := discord.QueryValues{}
 	if query != "" {
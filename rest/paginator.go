@@ -0,0 +1,236 @@
+package rest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// PageDirection is the direction a Paginator traverses a list endpoint in.
+type PageDirection int
+
+const (
+	// PageDirectionBefore traverses from newer to older items.
+	PageDirectionBefore PageDirection = iota
+	// PageDirectionAfter traverses from older to newer items.
+	PageDirectionAfter
+)
+
+// pageFunc fetches the page following the given cursor item, which is nil for the first page.
+// It returns the items in traversal order and whether there might be more pages.
+type pageFunc[T any] func(cursor *T) ([]T, bool, error)
+
+type pageResult[T any] struct {
+	items   []T
+	hasMore bool
+	err     error
+}
+
+// Paginator iterates over all items of a list endpoint one item at a time and fetches new pages as needed.
+// While the items of a page are consumed, the next page is already fetched in the background.
+//
+//	paginator := client.Rest().GetMessagesPaginator(channelID, rest.PageDirectionBefore, 0, 100).UntilTime(time.Now().Add(-24 * time.Hour))
+//	for paginator.Next() {
+//		message := paginator.Item()
+//	}
+//	if err := paginator.Err(); err != nil {
+//		// handle error
+//	}
+type Paginator[T any] struct {
+	fetchFunc pageFunc[T]
+	idFunc    func(item T) snowflake.ID
+	timeFunc  func(item T) time.Time
+	direction PageDirection
+
+	untilID   snowflake.ID
+	untilTime time.Time
+	maxItems  int
+	prefetch  bool
+
+	done    bool
+	items   []T
+	index   int
+	count   int
+	item    T
+	err     error
+	hasMore bool
+	pending chan pageResult[T]
+}
+
+func newPaginator[T any](fetchFunc pageFunc[T], idFunc func(item T) snowflake.ID, direction PageDirection) *Paginator[T] {
+	return &Paginator[T]{
+		fetchFunc: fetchFunc,
+		idFunc:    idFunc,
+		timeFunc: func(item T) time.Time {
+			return idFunc(item).Time()
+		},
+		direction: direction,
+		prefetch:  true,
+		hasMore:   true,
+	}
+}
+
+// newIDPaginator returns a Paginator for list endpoints which accept a before or after snowflake.ID.
+func newIDPaginator[T any](fetchFunc func(before snowflake.ID, after snowflake.ID) ([]T, error), idFunc func(item T) snowflake.ID, direction PageDirection, startID snowflake.ID, limit int) *Paginator[T] {
+	return newPaginator(func(cursor *T) ([]T, bool, error) {
+		id := startID
+		if cursor != nil {
+			id = idFunc(*cursor)
+		} else if id == 0 && direction == PageDirectionAfter {
+			// an after of 0 is not sent to discord, which makes some endpoints return the newest items instead of the oldest
+			id = 1
+		}
+
+		var (
+			items []T
+			err   error
+		)
+		if direction == PageDirectionBefore {
+			items, err = fetchFunc(id, 0)
+		} else {
+			items, err = fetchFunc(0, id)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		// discord doesn't return items in the same order for every endpoint
+		sort.Slice(items, func(i, j int) bool {
+			if direction == PageDirectionBefore {
+				return idFunc(items[i]) > idFunc(items[j])
+			}
+			return idFunc(items[i]) < idFunc(items[j])
+		})
+		return items, len(items) > 0 && (limit <= 0 || len(items) >= limit), nil
+	}, idFunc, direction)
+}
+
+// UntilID stops the Paginator once it reaches the given snowflake.ID. The item with the ID is not returned.
+// As archived threads are not sorted by their ID, use UntilTime for them instead.
+func (p *Paginator[T]) UntilID(id snowflake.ID) *Paginator[T] {
+	p.untilID = id
+	return p
+}
+
+// UntilTime stops the Paginator once it reaches an item created at or past the given time.
+// For archived threads the archive timestamp is used instead.
+func (p *Paginator[T]) UntilTime(t time.Time) *Paginator[T] {
+	p.untilTime = t
+	return p
+}
+
+// MaxItems stops the Paginator after the given number of items.
+func (p *Paginator[T]) MaxItems(maxItems int) *Paginator[T] {
+	p.maxItems = maxItems
+	return p
+}
+
+// Prefetch sets whether the next page should be fetched while the current one is consumed. It is enabled by default.
+func (p *Paginator[T]) Prefetch(prefetch bool) *Paginator[T] {
+	p.prefetch = prefetch
+	return p
+}
+
+// Item returns the current item. It is only valid after Next returned true.
+func (p *Paginator[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error which stopped the Paginator or nil if it stopped because there were no more items.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// Next advances the Paginator to the next item. It returns false once there are no more items or an error occurred.
+func (p *Paginator[T]) Next() bool {
+	if p.done {
+		return false
+	}
+	if p.maxItems > 0 && p.count >= p.maxItems {
+		return p.stop(nil)
+	}
+
+	for p.index >= len(p.items) {
+		if p.pending == nil {
+			if !p.hasMore {
+				return p.stop(nil)
+			}
+			p.fetch(p.cursor())
+		}
+		result := <-p.pending
+		p.pending = nil
+		if result.err != nil {
+			return p.stop(result.err)
+		}
+		p.items, p.index, p.hasMore = result.items, 0, result.hasMore
+		if len(p.items) == 0 {
+			return p.stop(nil)
+		}
+		if p.hasMore && p.prefetch {
+			p.fetch(p.cursor())
+		}
+	}
+
+	item := p.items[p.index]
+	if p.reachedBound(item) {
+		return p.stop(nil)
+	}
+	p.index++
+	p.count++
+	p.item = item
+	return true
+}
+
+// cursor returns the last item of the current page or nil if no page was fetched yet.
+func (p *Paginator[T]) cursor() *T {
+	if len(p.items) == 0 {
+		return nil
+	}
+	return &p.items[len(p.items)-1]
+}
+
+func (p *Paginator[T]) fetch(cursor *T) {
+	if cursor != nil {
+		// copy the cursor as the items might change while fetching
+		c := *cursor
+		cursor = &c
+	}
+	pending := make(chan pageResult[T], 1)
+	p.pending = pending
+	do := func() {
+		items, hasMore, err := p.fetchFunc(cursor)
+		pending <- pageResult[T]{items: items, hasMore: hasMore, err: err}
+	}
+	if p.prefetch {
+		go do()
+		return
+	}
+	do()
+}
+
+func (p *Paginator[T]) reachedBound(item T) bool {
+	if p.untilID != 0 {
+		id := p.idFunc(item)
+		if (p.direction == PageDirectionBefore && id <= p.untilID) || (p.direction == PageDirectionAfter && id >= p.untilID) {
+			return true
+		}
+	}
+	if !p.untilTime.IsZero() {
+		t := p.timeFunc(item)
+		if (p.direction == PageDirectionBefore && !t.After(p.untilTime)) || (p.direction == PageDirectionAfter && !t.Before(p.untilTime)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Paginator[T]) stop(err error) bool {
+	p.done = true
+	p.err = err
+	var zero T
+	p.item = zero
+	p.items = nil
+	p.pending = nil
+	return false
+}
@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIDPaginator(ids []snowflake.ID, direction PageDirection, limit int) (*Paginator[snowflake.ID], *int) {
+	var requests int
+	return newIDPaginator(func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error) {
+		requests++
+		var page []snowflake.ID
+		for _, id := range ids {
+			if (before != 0 && id < before) || (before == 0 && id > after) {
+				page = append(page, id)
+			}
+		}
+		if direction == PageDirectionBefore && len(page) > limit {
+			page = page[len(page)-limit:]
+		} else if len(page) > limit {
+			page = page[:limit]
+		}
+		return page, nil
+	}, func(id snowflake.ID) snowflake.ID {
+		return id
+	}, direction, 0, limit), &requests
+}
+
+func collect(p *Paginator[snowflake.ID]) []snowflake.ID {
+	var ids []snowflake.ID
+	for p.Next() {
+		ids = append(ids, p.Item())
+	}
+	return ids
+}
+
+func TestPaginator(t *testing.T) {
+	// ids start at 2 as paginating after 0 starts after 1
+	ids := []snowflake.ID{2, 3, 4, 5, 6, 7, 8}
+
+	p, requests := newTestIDPaginator(ids, PageDirectionAfter, 3)
+	assert.Equal(t, ids, collect(p))
+	assert.NoError(t, p.Err())
+	assert.Equal(t, 3, *requests)
+
+	p, _ = newTestIDPaginator(ids, PageDirectionBefore, 3)
+	assert.Equal(t, []snowflake.ID{8, 7, 6, 5, 4, 3, 2}, collect(p))
+
+	p, _ = newTestIDPaginator(ids, PageDirectionAfter, 3)
+	assert.Equal(t, []snowflake.ID{2, 3, 4, 5}, collect(p.UntilID(6)))
+
+	p, _ = newTestIDPaginator(ids, PageDirectionAfter, 3)
+	assert.Equal(t, []snowflake.ID{2, 3}, collect(p.MaxItems(2).Prefetch(false)))
+
+	p, _ = newTestIDPaginator([]snowflake.ID{0}, PageDirectionAfter, 3)
+	p.fetchFunc = func(*snowflake.ID) ([]snowflake.ID, bool, error) {
+		return nil, false, errors.New("test")
+	}
+	assert.False(t, p.Next())
+	assert.EqualError(t, p.Err(), "test")
+}
+
+// newPaginatorTestClient returns a Client whose list endpoints serve the given ids like discord does and records all request queries.
+// Without a before or after query the newest items are returned.
+func newPaginatorTestClient(t *testing.T, ids []snowflake.ID, encode func(ids []snowflake.ID) string) (Client, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, r.URL.RawQuery)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		before, _ := snowflake.Parse(query.Get("before"))
+		after, _ := snowflake.Parse(query.Get("after"))
+
+		var page []snowflake.ID
+		if query.Has("after") {
+			for _, id := range ids {
+				if id > after && len(page) < limit {
+					page = append(page, id)
+				}
+			}
+		} else {
+			for i := len(ids) - 1; i >= 0; i-- {
+				if (before == 0 || ids[i] < before) && len(page) < limit {
+					page = append(page, ids[i])
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(encode(page)))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient("token", WithURL(server.URL)), &queries
+}
+
+func encodeObjects(ids []snowflake.ID) string {
+	objects := make([]string, len(ids))
+	for i, id := range ids {
+		objects[i] = `{"id":"` + id.String() + `"}`
+	}
+	return "[" + strings.Join(objects, ",") + "]"
+}
+
+func TestEndpointPaginators(t *testing.T) {
+	ids := []snowflake.ID{10, 11, 12, 13, 14}
+
+	client, queries := newPaginatorTestClient(t, ids, encodeObjects)
+	var messageIDs []snowflake.ID
+	messages := NewChannels(client).GetMessagesPaginator(1, PageDirectionAfter, 0, 2)
+	for messages.Next() {
+		messageIDs = append(messageIDs, messages.Item().ID)
+	}
+	assert.NoError(t, messages.Err())
+	assert.Equal(t, ids, messageIDs)
+	assert.Equal(t, "after=1&limit=2", (*queries)[0])
+
+	client, _ = newPaginatorTestClient(t, ids, encodeObjects)
+	messageIDs = nil
+	messages = NewChannels(client).GetMessagesPaginator(1, PageDirectionBefore, 0, 2)
+	for messages.Next() {
+		messageIDs = append(messageIDs, messages.Item().ID)
+	}
+	assert.Equal(t, []snowflake.ID{14, 13, 12, 11, 10}, messageIDs)
+
+	client, queries = newPaginatorTestClient(t, ids, func(ids []snowflake.ID) string {
+		return `{"audit_log_entries":` + encodeObjects(ids) + `}`
+	})
+	var entryIDs []snowflake.ID
+	entries := NewGuilds(client).GetAuditLogPaginator(1, 0, 0, PageDirectionAfter, 0, 2)
+	for entries.Next() {
+		entryIDs = append(entryIDs, entries.Item().ID)
+	}
+	assert.NoError(t, entries.Err())
+	assert.Equal(t, ids, entryIDs)
+	assert.Contains(t, (*queries)[0], "after=1")
+
+	client, queries = newPaginatorTestClient(t, ids, encodeObjects)
+	var userIDs []snowflake.ID
+	users := NewChannels(client).GetReactionsPaginator(1, 2, "👍", 0, 2).UntilID(13)
+	for users.Next() {
+		userIDs = append(userIDs, users.Item().ID)
+	}
+	assert.NoError(t, users.Err())
+	assert.Equal(t, []snowflake.ID{10, 11, 12}, userIDs)
+	assert.Equal(t, "after=1&limit=2", (*queries)[0])
+}
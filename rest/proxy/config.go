@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/log"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:            log.Default(),
+		HTTPServer:        &http.Server{},
+		Address:           ":8080",
+		URL:               fmt.Sprintf("%s/v%d", rest.API, rest.Version),
+		ClientIdleTimeout: 10 * time.Minute,
+	}
+}
+
+// Config lets you configure your Server instance.
+type Config struct {
+	Logger               log.Logger
+	HTTPServer           *http.Server
+	Address              string
+	CertFile             string
+	KeyFile              string
+	URL                  string
+	RestClientConfigOpts []rest.ConfigOpt
+	ClientIdleTimeout    time.Duration
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the Config.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithHTTPServer sets the http.Server of the Config.
+func WithHTTPServer(httpServer *http.Server) ConfigOpt {
+	return func(config *Config) {
+		config.HTTPServer = httpServer
+	}
+}
+
+// WithAddress sets the Address of the Config.
+func WithAddress(address string) ConfigOpt {
+	return func(config *Config) {
+		config.Address = address
+	}
+}
+
+// WithTLS sets the CertFile & KeyFile of the Config.
+func WithTLS(certFile string, keyFile string) ConfigOpt {
+	return func(config *Config) {
+		config.CertFile = certFile
+		config.KeyFile = keyFile
+	}
+}
+
+// WithURL sets the upstream api url requests are forwarded to.
+func WithURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.URL = url
+	}
+}
+
+// WithRestClientConfigOpts applies rest.ConfigOpt(s) to the rest.Client created for every token.
+// Passing rest.WithRateLimiter shares the rest.RateLimiter between all tokens, which you usually don't want.
+func WithRestClientConfigOpts(opts ...rest.ConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.RestClientConfigOpts = append(config.RestClientConfigOpts, opts...)
+	}
+}
+
+// WithClientIdleTimeout sets after which duration without requests the rest.Client of a token is closed and removed.
+// A timeout of 0 keeps all rest.Client(s) until the Server is closed.
+func WithClientIdleTimeout(clientIdleTimeout time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ClientIdleTimeout = clientIdleTimeout
+	}
+}
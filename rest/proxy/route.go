@@ -0,0 +1,125 @@
+package proxy
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+var apiPrefix = regexp.MustCompile(`^/api(/v\d+)?`)
+
+// paramNames maps a path segment to the name of the parameter following it
+var paramNames = map[string]string{
+	"guilds":       "guild.id",
+	"channels":     "channel.id",
+	"webhooks":     "webhook.id",
+	"interactions": "interaction.id",
+	"messages":     "message.id",
+	"users":        "user.id",
+	"roles":        "role.id",
+	"members":      "user.id",
+	"bans":         "user.id",
+	"reactions":    "emoji",
+	"emojis":       "emoji.id",
+	"stickers":     "sticker.id",
+	"applications": "application.id",
+	"commands":     "command.id",
+	"threads":      "thread.id",
+}
+
+// majorParams contains the names of all rest.MajorParameters
+var majorParams = func() map[string]struct{} {
+	params := map[string]struct{}{}
+	for _, param := range strings.Split(rest.MajorParameters, ":") {
+		params[param] = struct{}{}
+	}
+	return params
+}()
+
+// tokenNames maps a parameter to the name of the token parameter following it
+var tokenNames = map[string]string{
+	"webhook.id":     "webhook.token",
+	"interaction.id": "interaction.token",
+}
+
+// compileEndpoint builds a rest.CompiledEndpoint from a raw request path so the request can be put into the correct rate limit bucket.
+// Parameters are replaced with placeholders the same way rest.Endpoint routes are defined, and major parameters are kept.
+func (s *serverImpl) compileEndpoint(method string, path string, rawQuery string) *rest.CompiledEndpoint {
+	path = apiPrefix.ReplaceAllString(path, "")
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	route := make([]string, len(segments))
+	var (
+		endpointMajorParams []string
+		prevParam           string
+	)
+	for i, segment := range segments {
+		var param string
+		if i > 0 && segment != "@me" && segment != "@original" {
+			if name, ok := tokenNames[prevParam]; ok {
+				param = name
+			} else if name, ok = paramNames[segments[i-1]]; ok && (name == "emoji" || isSnowflake(segment)) {
+				param = name
+			} else if isSnowflake(segment) {
+				param = "id"
+			}
+		}
+		prevParam = param
+		if param == "" {
+			route[i] = segment
+			continue
+		}
+		route[i] = "{" + param + "}"
+		if _, ok := majorParams[param]; ok {
+			endpointMajorParams = append(endpointMajorParams, param+"="+segment)
+		}
+	}
+
+	url := path
+	if rawQuery != "" {
+		url += "?" + rawQuery
+	}
+	return &rest.CompiledEndpoint{
+		Endpoint:    s.endpoint(method, "/"+strings.Join(route, "/")),
+		URL:         url,
+		MajorParams: strings.Join(endpointMajorParams, ":"),
+	}
+}
+
+func isSnowflake(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// hopHeaders are removed from proxied requests & responses as they only apply to a single connection
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+func copyHeader(dst http.Header, src http.Header) {
+	for key, values := range src {
+		for _, value := range values {
+			dst.Add(key, value)
+		}
+	}
+	for _, key := range hopHeaders {
+		dst.Del(key)
+	}
+}
@@ -0,0 +1,233 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// Server is a transparent proxy for Discord's REST API.
+// Requests are sent through a rest.Client per token, so multiple workers share the same rate limits.
+// Point your rest.Client to the proxy via rest.WithURL("http://proxy:8080/api/v10").
+type Server interface {
+	http.Handler
+
+	// Start starts the Server
+	Start()
+
+	// Close closes the Server and all rest.Client(s)
+	Close(ctx context.Context)
+}
+
+var _ Server = (*serverImpl)(nil)
+
+// New creates a new Server with the given ConfigOpt(s)
+func New(opts ...ConfigOpt) Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	s := &serverImpl{
+		config:    *config,
+		clients:   map[string]*proxyClient{},
+		endpoints: map[string]*rest.Endpoint{},
+		closed:    make(chan struct{}),
+	}
+	if s.config.ClientIdleTimeout > 0 {
+		go s.cleanup()
+	}
+	return s
+}
+
+type proxyClient struct {
+	rest.Client
+	lastUsed time.Time
+	// number of requests currently using this client
+	active int
+}
+
+type serverImpl struct {
+	config Config
+
+	// Authorization header -> rest.Client
+	clients   map[string]*proxyClient
+	clientsMu sync.Mutex
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// the rest.RateLimiter caches route hashes by *rest.Endpoint, so we need to reuse them
+	endpoints   map[string]*rest.Endpoint
+	endpointsMu sync.Mutex
+}
+
+func (s *serverImpl) Start() {
+	s.config.HTTPServer.Addr = s.config.Address
+	s.config.HTTPServer.Handler = s
+
+	go func() {
+		var err error
+		if s.config.CertFile != "" && s.config.KeyFile != "" {
+			err = s.config.HTTPServer.ListenAndServeTLS(s.config.CertFile, s.config.KeyFile)
+		} else {
+			err = s.config.HTTPServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			s.config.Logger.Error("error while running rest proxy server: ", err)
+		}
+	}()
+}
+
+func (s *serverImpl) Close(ctx context.Context) {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+	_ = s.config.HTTPServer.Shutdown(ctx)
+
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	for _, client := range s.clients {
+		client.Close(ctx)
+	}
+}
+
+// client returns the rest.Client for the given Authorization header. Every token gets its own rest.Client to isolate their rate limits.
+// The returned client must be released via releaseClient once the request is done.
+func (s *serverImpl) client(authorization string) *proxyClient {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	client, ok := s.clients[authorization]
+	if !ok {
+		client = &proxyClient{Client: rest.NewClient(authorization, s.config.RestClientConfigOpts...)}
+		s.clients[authorization] = client
+	}
+	client.active++
+	client.lastUsed = time.Now()
+	return client
+}
+
+func (s *serverImpl) releaseClient(client *proxyClient) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	client.active--
+	client.lastUsed = time.Now()
+}
+
+// cleanup periodically closes & removes rest.Client(s) which were not used for the configured Config.ClientIdleTimeout.
+// This also makes sure clients of invalid or rotated tokens don't pile up.
+func (s *serverImpl) cleanup() {
+	ticker := time.NewTicker(s.config.ClientIdleTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+			s.removeIdleClients()
+		}
+	}
+}
+
+func (s *serverImpl) removeIdleClients() {
+	s.clientsMu.Lock()
+	now := time.Now()
+	var idle []*proxyClient
+	for authorization, client := range s.clients {
+		if client.active == 0 && now.Sub(client.lastUsed) >= s.config.ClientIdleTimeout {
+			idle = append(idle, client)
+			delete(s.clients, authorization)
+		}
+	}
+	s.clientsMu.Unlock()
+
+	for _, client := range idle {
+		client.Close(context.Background())
+	}
+	if len(idle) > 0 {
+		s.config.Logger.Debugf("removed %d idle rest clients", len(idle))
+	}
+}
+
+func (s *serverImpl) endpoint(method string, route string) *rest.Endpoint {
+	s.endpointsMu.Lock()
+	defer s.endpointsMu.Unlock()
+
+	key := method + "+" + route
+	endpoint, ok := s.endpoints[key]
+	if !ok {
+		endpoint = &rest.Endpoint{
+			Method: method,
+			Route:  route,
+		}
+		s.endpoints[key] = endpoint
+	}
+	return endpoint
+}
+
+func (s *serverImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the body is buffered, so we can resend it when we hit a rate limit
+	rqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.config.Logger.Error("error reading request body in rest proxy: ", err)
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	endpoint := s.compileEndpoint(r.Method, r.URL.Path, r.URL.RawQuery)
+	client := s.client(r.Header.Get("Authorization"))
+	defer s.releaseClient(client)
+
+	rs, err := s.do(r, client, endpoint, rqBody)
+	if err != nil {
+		if r.Context().Err() != nil {
+			return
+		}
+		s.config.Logger.Error("error proxying request: ", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer rs.Body.Close()
+
+	copyHeader(w.Header(), rs.Header)
+	w.WriteHeader(rs.StatusCode)
+	if _, err = io.Copy(w, rs.Body); err != nil {
+		s.config.Logger.Error("error writing response body in rest proxy: ", err)
+	}
+}
+
+func (s *serverImpl) do(r *http.Request, client rest.Client, endpoint *rest.CompiledEndpoint, rqBody []byte) (*http.Response, error) {
+	for tries := 1; ; tries++ {
+		if err := client.RateLimiter().WaitBucket(r.Context(), endpoint); err != nil {
+			return nil, fmt.Errorf("error locking bucket in rest proxy: %w", err)
+		}
+
+		// headers like Content-Type of multipart bodies or X-Audit-Log-Reason are passed through as is
+		rq, err := http.NewRequestWithContext(r.Context(), r.Method, s.config.URL+endpoint.URL, bytes.NewReader(rqBody))
+		if err != nil {
+			_ = client.RateLimiter().UnlockBucket(endpoint, nil)
+			return nil, err
+		}
+		copyHeader(rq.Header, r.Header)
+
+		rs, err := client.HTTPClient().Do(rq)
+		if err != nil {
+			_ = client.RateLimiter().UnlockBucket(endpoint, nil)
+			return nil, fmt.Errorf("error doing request in rest proxy: %w", err)
+		}
+
+		if err = client.RateLimiter().UnlockBucket(endpoint, rs); err != nil {
+			s.config.Logger.Error("error unlocking bucket in rest proxy: ", err)
+		}
+
+		if rs.StatusCode != http.StatusTooManyRequests || tries >= client.RateLimiter().MaxRetries() {
+			return rs, nil
+		}
+		_ = rs.Body.Close()
+	}
+}
@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileEndpoint(t *testing.T) {
+	s := New().(*serverImpl)
+
+	data := []struct {
+		path        string
+		route       string
+		majorParams string
+	}{
+		{"/api/v10/channels/123/messages/456", "/channels/{channel.id}/messages/{message.id}", "channel.id=123"},
+		{"/api/v10/guilds/123/members/search", "/guilds/{guild.id}/members/search", "guild.id=123"},
+		{"/api/v10/channels/123/messages/456/reactions/%F0%9F%91%8D/@me", "/channels/{channel.id}/messages/{message.id}/reactions/{emoji}/@me", "channel.id=123"},
+		{"/api/v10/webhooks/123/abc/messages/@original", "/webhooks/{webhook.id}/{webhook.token}/messages/@original", "webhook.id=123"},
+		{"/api/v10/interactions/123/abc/callback", "/interactions/{interaction.id}/{interaction.token}/callback", "interaction.token=abc"},
+		{"/api/v10/skus/123/subscriptions", "/skus/{id}/subscriptions", ""},
+		{"/users/@me", "/users/@me", ""},
+	}
+	for _, d := range data {
+		endpoint := s.compileEndpoint(http.MethodGet, d.path, "")
+		assert.Equal(t, d.route, endpoint.Endpoint.Route, d.path)
+		assert.Equal(t, d.majorParams, endpoint.MajorParams, d.path)
+	}
+
+	assert.Same(t, s.compileEndpoint(http.MethodGet, "/channels/1", "").Endpoint, s.compileEndpoint(http.MethodGet, "/channels/2", "").Endpoint)
+}
+
+func TestServerProxiesRequests(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "/channels/123/messages", r.URL.Path)
+		assert.Equal(t, "multipart/form-data; boundary=abc", r.Header.Get("Content-Type"))
+		assert.Equal(t, "some%20reason", r.Header.Get("X-Audit-Log-Reason"))
+		assert.Equal(t, "--abc--", string(body))
+
+		w.Header().Set("X-RateLimit-Bucket", "bucket")
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset-After", "1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer upstream.Close()
+
+	s := New(WithURL(upstream.URL)).(*serverImpl)
+
+	for _, token := range []string{"Bot a", "Bot b"} {
+		rq := httptest.NewRequest(http.MethodPost, "/api/v10/channels/123/messages", bytes.NewReader([]byte("--abc--")))
+		rq.Header.Set("Authorization", token)
+		rq.Header.Set("Content-Type", "multipart/form-data; boundary=abc")
+		rq.Header.Set("X-Audit-Log-Reason", "some%20reason")
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, rq)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, `{"id":"1"}`, rec.Body.String())
+		assert.Equal(t, "4", rec.Header().Get("X-RateLimit-Remaining"))
+	}
+	assert.Len(t, s.clients, 2)
+}
+
+func TestServerRemovesIdleClients(t *testing.T) {
+	s := New(WithClientIdleTimeout(time.Hour)).(*serverImpl)
+	defer s.Close(context.Background())
+
+	idle := s.client("Bot a")
+	s.releaseClient(idle)
+	idle.lastUsed = time.Now().Add(-2 * time.Hour)
+
+	// clients with requests in flight are never removed
+	active := s.client("Bot b")
+	active.lastUsed = time.Now().Add(-2 * time.Hour)
+
+	recent := s.client("Bot c")
+	s.releaseClient(recent)
+
+	s.removeIdleClients()
+	assert.NotContains(t, s.clients, "Bot a")
+	assert.Contains(t, s.clients, "Bot b")
+	assert.Contains(t, s.clients, "Bot c")
+}
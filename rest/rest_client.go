@@ -21,10 +21,12 @@ func NewClient(botToken string, opts ...ConfigOpt) Client {
 
 	config.RateLimiter.Reset()
 
-	return &clientImpl{
+	client := &clientImpl{
 		botToken: botToken,
 		config:   *config,
 	}
+	client.roundTrip = chainMiddlewares(client.doRequest, config.Middlewares)
+	return client
 }
 
 // Client allows doing requests to different endpoints
@@ -43,8 +45,9 @@ type Client interface {
 }
 
 type clientImpl struct {
-	botToken string
-	config   Config
+	botToken  string
+	config    Config
+	roundTrip RoundTripFunc
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
@@ -119,7 +122,7 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 	if err != nil {
 		return fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
-	rq = rq.WithContext(config.Ctx)
+	rq = config.Request.WithContext(config.Ctx)
 
 	for _, check := range config.Checks {
 		if !check() {
@@ -128,11 +131,20 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		}
 	}
 
-	rs, err := c.HTTPClient().Do(config.Request)
+	rs, err := c.roundTrip(&Request{
+		Endpoint: endpoint,
+		Body:     rawRqBody,
+		Request:  rq,
+		Try:      tries,
+	})
 	if err != nil {
 		_ = c.RateLimiter().UnlockBucket(endpoint, nil)
 		return fmt.Errorf("error doing request in rest client: %w", err)
 	}
+	if rs == nil {
+		_ = c.RateLimiter().UnlockBucket(endpoint, nil)
+		return ErrNoResponse
+	}
 
 	if err = c.RateLimiter().UnlockBucket(endpoint, rs); err != nil {
 		return fmt.Errorf("error unlocking bucket in rest client: %w", err)
@@ -168,6 +180,10 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 	}
 }
 
+func (c *clientImpl) doRequest(rq *Request) (*http.Response, error) {
+	return c.HTTPClient().Do(rq.Request)
+}
+
 func (c *clientImpl) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
 	return c.retry(endpoint, rqBody, rsBody, 1, opts)
 }
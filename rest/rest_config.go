@@ -25,6 +25,7 @@ type Config struct {
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
 	URL                       string
 	UserAgent                 string
+	Middlewares               []Middleware
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -81,3 +82,10 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithMiddlewares adds Middleware(s) which wrap every request made by the rest client. The first Middleware is the outermost one
+func WithMiddlewares(middlewares ...Middleware) ConfigOpt {
+	return func(config *Config) {
+		config.Middlewares = append(config.Middlewares, middlewares...)
+	}
+}
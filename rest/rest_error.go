@@ -3,6 +3,10 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/json"
 )
 
 var _ error = (*Error)(nil)
@@ -13,20 +17,96 @@ type Error struct {
 	RqBody   []byte
 	Response *http.Response
 	RsBody   []byte
+
+	// Code is the JSONErrorCode discord returned. It is 0 if the response body is no discord error
+	Code JSONErrorCode
+	// Message is the error message discord returned
+	Message string
+	// Errors are the flattened validation errors per field
+	Errors []FieldError
+}
+
+// FieldError is a single validation error of a field in the request body.
+type FieldError struct {
+	// Path is the path of the field separated by dots, e.g. "embeds.0.description"
+	Path    string
+	Code    string
+	Message string
+}
+
+// Error returns the error formatted as string
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", e.Path, e.Message, e.Code)
 }
 
 // NewError returns a new Error with the given http.Request, http.Response
 func NewError(rq *http.Request, rqBody []byte, rs *http.Response, rsBody []byte) error {
-	return &Error{
+	err := &Error{
 		Request:  rq,
 		RqBody:   rqBody,
 		Response: rs,
 		RsBody:   rsBody,
 	}
+
+	var v struct {
+		Code    JSONErrorCode   `json:"code"`
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	if jsonErr := json.Unmarshal(rsBody, &v); jsonErr == nil {
+		err.Code = v.Code
+		err.Message = v.Message
+		err.Errors = flattenFieldErrors("", v.Errors, nil)
+	}
+	return err
 }
 
-// Is returns true if the error is a discord.APIError 6 has the same StatusCode
+// flattenFieldErrors walks discord's nested errors object & collects all "_errors" with the path of their field.
+func flattenFieldErrors(path string, data json.RawMessage, fieldErrors []FieldError) []FieldError {
+	var fields map[string]json.RawMessage
+	if len(data) == 0 || json.Unmarshal(data, &fields) != nil {
+		return fieldErrors
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "_errors" {
+			var errs []struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			if json.Unmarshal(fields[key], &errs) != nil {
+				continue
+			}
+			for _, err := range errs {
+				fieldErrors = append(fieldErrors, FieldError{
+					Path:    path,
+					Code:    err.Code,
+					Message: err.Message,
+				})
+			}
+			continue
+		}
+
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		fieldErrors = flattenFieldErrors(fieldPath, fields[key], fieldErrors)
+	}
+	return fieldErrors
+}
+
+// Is returns true if the target is a JSONErrorCode matching the Code, or an *Error which has the same StatusCode
 func (e Error) Is(target error) bool {
+	if code, ok := target.(JSONErrorCode); ok {
+		return e.Code == code
+	}
 	err, ok := target.(*Error)
 	if !ok {
 		return false
@@ -36,10 +116,21 @@ func (e Error) Is(target error) bool {
 
 // Error returns the error formatted as string
 func (e Error) Error() string {
-	if e.Response != nil {
+	if e.Response == nil {
+		return "unknown error"
+	}
+	if e.Code == 0 {
 		return fmt.Sprintf("Status: %s, Body: %s", e.Response.Status, string(e.RsBody))
 	}
-	return "unknown error"
+	str := fmt.Sprintf("Status: %s, Code: %d, Message: %s", e.Response.Status, e.Code, e.Message)
+	if len(e.Errors) > 0 {
+		fieldErrors := make([]string, len(e.Errors))
+		for i, err := range e.Errors {
+			fieldErrors[i] = err.Error()
+		}
+		str += ", Errors: " + strings.Join(fieldErrors, ", ")
+	}
+	return str
 }
 
 // Error returns the error formatted as string
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"fmt"
+)
+
+// JSONErrorCode is the error code discord returns in the JSON body of a failed request (https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes).
+// It implements error, so it can be used with errors.Is to check the code of an Error.
+//
+//	if errors.Is(err, rest.ErrUnknownMessage) {
+//		// the message was already deleted
+//	}
+type JSONErrorCode int
+
+// Error returns the JSONErrorCode formatted as string
+func (c JSONErrorCode) Error() string {
+	return fmt.Sprintf("discord json error code %d", int(c))
+}
+
+const (
+	JSONErrorCodeUnknownAccount                  JSONErrorCode = 10001
+	JSONErrorCodeUnknownApplication              JSONErrorCode = 10002
+	JSONErrorCodeUnknownChannel                  JSONErrorCode = 10003
+	JSONErrorCodeUnknownGuild                    JSONErrorCode = 10004
+	JSONErrorCodeUnknownIntegration              JSONErrorCode = 10005
+	JSONErrorCodeUnknownInvite                   JSONErrorCode = 10006
+	JSONErrorCodeUnknownMember                   JSONErrorCode = 10007
+	JSONErrorCodeUnknownMessage                  JSONErrorCode = 10008
+	JSONErrorCodeUnknownPermissionOverwrite      JSONErrorCode = 10009
+	JSONErrorCodeUnknownRole                     JSONErrorCode = 10011
+	JSONErrorCodeUnknownToken                    JSONErrorCode = 10012
+	JSONErrorCodeUnknownUser                     JSONErrorCode = 10013
+	JSONErrorCodeUnknownEmoji                    JSONErrorCode = 10014
+	JSONErrorCodeUnknownWebhook                  JSONErrorCode = 10015
+	JSONErrorCodeUnknownBan                      JSONErrorCode = 10026
+	JSONErrorCodeUnknownInteraction              JSONErrorCode = 10062
+	JSONErrorCodeUnknownApplicationCommand       JSONErrorCode = 10063
+	JSONErrorCodeMaximumNumberOfGuildsReached    JSONErrorCode = 30001
+	JSONErrorCodeMaximumNumberOfReactionsReached JSONErrorCode = 30010
+	JSONErrorCodeUnauthorized                    JSONErrorCode = 40001
+	JSONErrorCodeInteractionAlreadyAcknowledged  JSONErrorCode = 40060
+	JSONErrorCodeMissingAccess                   JSONErrorCode = 50001
+	JSONErrorCodeInvalidAccountType              JSONErrorCode = 50002
+	JSONErrorCodeCannotExecuteActionOnDMChannel  JSONErrorCode = 50003
+	JSONErrorCodeCannotSendMessagesToUser        JSONErrorCode = 50007
+	JSONErrorCodeMissingPermissions              JSONErrorCode = 50013
+	JSONErrorCodeInvalidToken                    JSONErrorCode = 50014
+	JSONErrorCodeInvalidWebhookToken             JSONErrorCode = 50027
+	JSONErrorCodeInvalidFormBody                 JSONErrorCode = 50035
+)
+
+// Sentinel errors for common JSONErrorCode(s) which can be used with errors.Is
+var (
+	ErrUnknownChannel                 error = JSONErrorCodeUnknownChannel
+	ErrUnknownGuild                   error = JSONErrorCodeUnknownGuild
+	ErrUnknownMember                  error = JSONErrorCodeUnknownMember
+	ErrUnknownMessage                 error = JSONErrorCodeUnknownMessage
+	ErrUnknownRole                    error = JSONErrorCodeUnknownRole
+	ErrUnknownUser                    error = JSONErrorCodeUnknownUser
+	ErrUnknownWebhook                 error = JSONErrorCodeUnknownWebhook
+	ErrUnknownInteraction             error = JSONErrorCodeUnknownInteraction
+	ErrInteractionAlreadyAcknowledged error = JSONErrorCodeInteractionAlreadyAcknowledged
+	ErrMissingAccess                  error = JSONErrorCodeMissingAccess
+	ErrCannotSendMessagesToUser       error = JSONErrorCodeCannotSendMessagesToUser
+	ErrMissingPermissions             error = JSONErrorCodeMissingPermissions
+	ErrInvalidFormBody                error = JSONErrorCodeInvalidFormBody
+)
@@ -0,0 +1,28 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewError(t *testing.T) {
+	rsBody := []byte(`{"code":50035,"message":"Invalid Form Body","errors":{"embeds":{"0":{"description":{"_errors":[{"code":"BASE_TYPE_MAX_LENGTH","message":"Must be 4096 or fewer in length."}]}}},"content":{"_errors":[{"code":"BASE_TYPE_REQUIRED","message":"This field is required"}]}}}`)
+	err := NewError(nil, nil, &http.Response{Status: "400 Bad Request", StatusCode: http.StatusBadRequest}, rsBody)
+
+	var restErr *Error
+	assert.True(t, errors.As(err, &restErr))
+	assert.Equal(t, JSONErrorCodeInvalidFormBody, restErr.Code)
+	assert.Equal(t, "Invalid Form Body", restErr.Message)
+	assert.Equal(t, []FieldError{
+		{Path: "content", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+		{Path: "embeds.0.description", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 4096 or fewer in length."},
+	}, restErr.Errors)
+
+	wrapped := fmt.Errorf("failed to create message: %w", err)
+	assert.ErrorIs(t, wrapped, ErrInvalidFormBody)
+	assert.NotErrorIs(t, wrapped, ErrUnknownMessage)
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// ErrNoResponse is returned when a Middleware returns neither an http.Response nor an error.
+var ErrNoResponse = errors.New("middleware returned no response and no error")
+
+// Request is a single attempt of a request made by the Client. It is passed through all Middleware(s) of the Client.
+type Request struct {
+	// Endpoint is the CompiledEndpoint the request is made to
+	Endpoint *CompiledEndpoint
+	// Body is the raw request body. Changing it has no effect on the http.Request
+	Body []byte
+	// Request is the http.Request which gets sent to discord
+	Request *http.Request
+	// Try is the number of the attempt, starting at 1. It increases for every retry caused by a rate limit
+	Try int
+}
+
+// RoundTripFunc sends the given Request and returns the http.Response.
+type RoundTripFunc func(rq *Request) (*http.Response, error)
+
+// Middleware wraps the RoundTripFunc of the Client. It can inspect or modify the Request & http.Response, or not call the next RoundTripFunc at all and return its own http.Response.
+// Middleware(s) run after waiting for the rate limit and for every retry.
+//
+//	// fault injection
+//	func(next rest.RoundTripFunc) rest.RoundTripFunc {
+//		return func(rq *rest.Request) (*http.Response, error) {
+//			if rq.Endpoint.Endpoint == rest.CreateMessage {
+//				return nil, errors.New("injected fault")
+//			}
+//			return next(rq)
+//		}
+//	}
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chainMiddlewares wraps the given RoundTripFunc with the given Middleware(s). The first Middleware is the outermost one.
+func chainMiddlewares(roundTrip RoundTripFunc, middlewares []Middleware) RoundTripFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		roundTrip = middlewares[i](roundTrip)
+	}
+	return roundTrip
+}
+
+// NewLoggerMiddleware returns a Middleware which logs every request with its latency.
+// Only the route of the Endpoint is logged, so webhook & interaction tokens in the url and the Authorization header never end up in logs.
+func NewLoggerMiddleware(logger log.Logger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(rq *Request) (*http.Response, error) {
+			start := time.Now()
+			rs, err := next(rq)
+			latency := time.Since(start)
+			if err != nil {
+				logger.Debugf("%s %s (try %d) failed after %s: %s", rq.Endpoint.Endpoint.Method, rq.Endpoint.Endpoint.Route, rq.Try, latency, err)
+				return rs, err
+			}
+			var statusCode int
+			if rs != nil {
+				statusCode = rs.StatusCode
+			}
+			logger.Debugf("%s %s (try %d) -> %d in %s", rq.Endpoint.Endpoint.Method, rq.Endpoint.Endpoint.Route, rq.Try, statusCode, latency)
+			return rs, err
+		}
+	}
+}
+
+// LatencyObserver is called with the latency of every request made to an Endpoint. statusCode is 0 if the request failed.
+type LatencyObserver func(endpoint *Endpoint, statusCode int, latency time.Duration)
+
+// NewLatencyMiddleware returns a Middleware which reports the latency of every request to the given LatencyObserver.
+// This can be used to feed histograms per Endpoint.
+func NewLatencyMiddleware(observer LatencyObserver) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(rq *Request) (*http.Response, error) {
+			start := time.Now()
+			rs, err := next(rq)
+			var statusCode int
+			if rs != nil {
+				statusCode = rs.StatusCode
+			}
+			observer(rq.Endpoint.Endpoint, statusCode, time.Since(start))
+			return rs, err
+		}
+	}
+}
+
+// SpanStarter starts a tracing span for the given Request and returns the context carrying the span and a func to end it.
+// The end func is called with the http.Response or error of the request. statusCode is 0 if the request failed.
+type SpanStarter func(ctx context.Context, rq *Request) (context.Context, func(statusCode int, err error))
+
+// NewTracingMiddleware returns a Middleware which wraps every request in a span started by the given SpanStarter.
+// The returned context is passed on with the http.Request, so instrumented http.RoundTripper(s) can pick up the span.
+//
+//	rest.NewTracingMiddleware(func(ctx context.Context, rq *rest.Request) (context.Context, func(int, error)) {
+//		ctx, span := tracer.Start(ctx, rq.Endpoint.Endpoint.Method+" "+rq.Endpoint.Endpoint.Route)
+//		return ctx, func(statusCode int, err error) {
+//			span.SetAttributes(attribute.Int("http.status_code", statusCode))
+//			span.End()
+//		}
+//	})
+func NewTracingMiddleware(startSpan SpanStarter) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(rq *Request) (*http.Response, error) {
+			ctx, end := startSpan(rq.Request.Context(), rq)
+			rq.Request = rq.Request.WithContext(ctx)
+			rs, err := next(rq)
+			var statusCode int
+			if rs != nil {
+				statusCode = rs.StatusCode
+			}
+			end(statusCode, err)
+			return rs, err
+		}
+	}
+}
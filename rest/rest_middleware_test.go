@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct {
+	log.Logger
+	lines []string
+}
+
+func (l *testLogger) Debugf(format string, args ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func newMiddlewareTestClient(t *testing.T, middlewares ...Middleware) Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient("token", WithURL(server.URL), WithMiddlewares(middlewares...))
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(rq *Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				rs, err := next(rq)
+				calls = append(calls, name+" after")
+				return rs, err
+			}
+		}
+	}
+	client := newMiddlewareTestClient(t, middleware("a"), middleware("b"))
+
+	assert.NoError(t, client.Do(GetBotApplicationInfo.Compile(nil), nil, nil))
+	assert.Equal(t, []string{"a before", "b before", "b after", "a after"}, calls)
+}
+
+func TestMiddlewareNoResponse(t *testing.T) {
+	fault := true
+	client := newMiddlewareTestClient(t, func(next RoundTripFunc) RoundTripFunc {
+		return func(rq *Request) (*http.Response, error) {
+			if fault {
+				return nil, nil
+			}
+			return next(rq)
+		}
+	})
+
+	assert.ErrorIs(t, client.Do(GetBotApplicationInfo.Compile(nil), nil, nil), ErrNoResponse)
+
+	// the bucket must have been unlocked again
+	fault = false
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, client.Do(GetBotApplicationInfo.Compile(nil), nil, nil, WithCtx(ctx)))
+}
+
+func TestLoggerMiddlewareRedactsTokens(t *testing.T) {
+	logger := &testLogger{}
+	client := newMiddlewareTestClient(t, NewLoggerMiddleware(logger))
+
+	assert.NoError(t, client.Do(DeleteWebhookMessage.Compile(nil, 1, "secret", 2), nil, nil))
+	if assert.Len(t, logger.lines, 1) {
+		assert.NotContains(t, logger.lines[0], "secret")
+		assert.Contains(t, logger.lines[0], "/webhooks/{webhook.id}/{webhook.token}/messages/{message.id}")
+	}
+}
+
+type spanKey struct{}
+
+func TestTracingMiddleware(t *testing.T) {
+	var (
+		ended      bool
+		statusCode int
+		spanSeen   bool
+	)
+	client := newMiddlewareTestClient(t,
+		NewTracingMiddleware(func(ctx context.Context, rq *Request) (context.Context, func(int, error)) {
+			return context.WithValue(ctx, spanKey{}, "span"), func(code int, err error) {
+				ended = true
+				statusCode = code
+				assert.NoError(t, err)
+			}
+		}),
+		func(next RoundTripFunc) RoundTripFunc {
+			return func(rq *Request) (*http.Response, error) {
+				spanSeen = rq.Request.Context().Value(spanKey{}) == "span"
+				return next(rq)
+			}
+		},
+	)
+
+	assert.NoError(t, client.Do(GetBotApplicationInfo.Compile(nil), nil, nil))
+	assert.True(t, spanSeen)
+	assert.True(t, ended)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+}
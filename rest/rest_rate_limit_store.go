@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ RateLimitStore = (*memoryRateLimitStore)(nil)
+
+// NewMemoryRateLimitStore returns a new RateLimitStore which keeps its state in memory.
+// It can be used to share rate limits between multiple clients in the same process.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		locks:    map[string]*memoryLock{},
+		buckets:  map[string]RateLimitBucket{},
+		hashes:   map[string]string{},
+		counters: map[string]*memoryCounter{},
+	}
+}
+
+type memoryLock struct {
+	// released is closed once the lock is released
+	released chan struct{}
+	timer    *time.Timer
+}
+
+type memoryCounter struct {
+	count int
+	reset time.Time
+}
+
+type memoryRateLimitStore struct {
+	mu       sync.Mutex
+	locks    map[string]*memoryLock
+	buckets  map[string]RateLimitBucket
+	hashes   map[string]string
+	counters map[string]*memoryCounter
+}
+
+func (s *memoryRateLimitStore) Lock(ctx context.Context, key string, ttl time.Duration) (func(), error) {
+	for {
+		s.mu.Lock()
+		lock, ok := s.locks[key]
+		if !ok {
+			lock = &memoryLock{released: make(chan struct{})}
+			s.locks[key] = lock
+			if ttl > 0 {
+				lock.timer = time.AfterFunc(ttl, func() {
+					s.release(key, lock)
+				})
+			}
+			s.mu.Unlock()
+
+			var once sync.Once
+			return func() {
+				once.Do(func() {
+					s.release(key, lock)
+				})
+			}, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-lock.released:
+		}
+	}
+}
+
+func (s *memoryRateLimitStore) release(key string, lock *memoryLock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the lock might already be released by its ttl and re-acquired by someone else
+	if s.locks[key] != lock {
+		return
+	}
+	if lock.timer != nil {
+		lock.timer.Stop()
+	}
+	delete(s.locks, key)
+	close(lock.released)
+}
+
+func (s *memoryRateLimitStore) Bucket(key string) (RateLimitBucket, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	return b, ok, nil
+}
+
+func (s *memoryRateLimitStore) SetBucket(key string, bucket RateLimitBucket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buckets[key] = bucket
+	return nil
+}
+
+func (s *memoryRateLimitStore) BucketHash(key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash, ok := s.hashes[key]
+	return hash, ok, nil
+}
+
+func (s *memoryRateLimitStore) SetBucketHash(key string, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hashes[key] = hash
+	return nil
+}
+
+func (s *memoryRateLimitStore) Incr(key string, window time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.reset) {
+		counter = &memoryCounter{reset: now.Add(window)}
+		s.counters[key] = counter
+	}
+	counter.count++
+	return counter.count, counter.reset, nil
+}
+
+func (s *memoryRateLimitStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, lock := range s.locks {
+		if lock.timer != nil {
+			lock.timer.Stop()
+		}
+		close(lock.released)
+	}
+	s.locks = map[string]*memoryLock{}
+	s.buckets = map[string]RateLimitBucket{}
+	s.hashes = map[string]string{}
+	s.counters = map[string]*memoryCounter{}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/disgoorg/log"
 	"github.com/sasha-s/go-csync"
 )
 
@@ -145,9 +146,11 @@ func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bu
 		}
 
 		b = &bucket{
-			Remaining: 1,
-			// we don't know the limit yet
-			Limit: -1,
+			RateLimitBucket: RateLimitBucket{
+				Remaining: 1,
+				// we don't know the limit yet
+				Limit: -1,
+			},
 		}
 		l.buckets[hash] = b
 	}
@@ -196,18 +199,29 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 		b.mu.Unlock()
 	}()
 
-	// no response provided means we can't update anything and just unlock it
-	if rs == nil || rs.Header == nil {
-		return nil
+	globalReset, err := applyRateLimitHeaders(l.config.Logger, endpoint, rs, &b.RateLimitBucket)
+	if !globalReset.IsZero() {
+		l.global = globalReset
 	}
-	bucketHeader := rs.Header.Get("X-RateLimit-Bucket")
+	return err
+}
 
-	// if we don't have a bucket header, we can't update anything
-	if bucketHeader == "" {
-		return nil
-	}
+// RateLimitBucket is the rate limit state of a single bucket.
+type RateLimitBucket struct {
+	ID        string
+	Reset     time.Time
+	Remaining int
+	Limit     int
+}
 
-	b.ID = bucketHeader
+// applyRateLimitHeaders updates the given RateLimitBucket from the rate limit headers of the given http.Response.
+// If the response is a global or cloudflare rate limit, the time until new requests can be made is returned instead.
+func applyRateLimitHeaders(logger log.Logger, endpoint *CompiledEndpoint, rs *http.Response, b *RateLimitBucket) (time.Time, error) {
+	// no response provided means we can't update anything
+	if rs == nil || rs.Header == nil {
+		return time.Time{}, nil
+	}
+	bucketHeader := rs.Header.Get("X-RateLimit-Bucket")
 
 	global := rs.Header.Get("X-RateLimit-Global") != ""
 	cloudflare := rs.Header.Get("via") == ""
@@ -217,33 +231,42 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 	resetAfterHeader := rs.Header.Get("X-RateLimit-Reset-After")
 	retryAfterHeader := rs.Header.Get("Retry-After")
 
-	l.config.Logger.Tracef("code: %d, headers: global %t, cloudflare: %t, remaining: %s, limit: %s, reset: %s, retryAfter: %s", rs.StatusCode, global, cloudflare, remainingHeader, limitHeader, resetHeader, retryAfterHeader)
+	logger.Tracef("code: %d, headers: global %t, cloudflare: %t, remaining: %s, limit: %s, reset: %s, retryAfter: %s", rs.StatusCode, global, cloudflare, remainingHeader, limitHeader, resetHeader, retryAfterHeader)
 
 	// we hit a rate limit. let's see if it was global cloudflare or a route specific one
 	if rs.StatusCode == http.StatusTooManyRequests {
-		retryAfter, err := strconv.Atoi(retryAfterHeader)
+		retryAfter, err := strconv.ParseFloat(retryAfterHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
+			return time.Time{}, fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
 		}
-		reset := time.Now().Add(time.Second * time.Duration(retryAfter))
+		reset := time.Now().Add(time.Duration(retryAfter * float64(time.Second)))
 		if global {
-			l.global = reset
-			l.config.Logger.Warnf("global rate limit exceeded, retry after: %ds", retryAfter)
+			logger.Warnf("global rate limit exceeded, retry after: %ss", retryAfterHeader)
+			return reset, nil
 		} else if cloudflare {
-			l.global = reset
-			l.config.Logger.Warnf("cloudflare rate limit exceeded, retry after: %ds", retryAfter)
-		} else {
-			b.Remaining = 0
-			b.Reset = reset
-			l.config.Logger.Warnf("rate limit on route %s exceeded, retry after: %ds", endpoint.URL, retryAfter)
+			logger.Warnf("cloudflare rate limit exceeded, retry after: %ss", retryAfterHeader)
+			return reset, nil
 		}
-		return nil
+		if bucketHeader != "" {
+			b.ID = bucketHeader
+		}
+		b.Remaining = 0
+		b.Reset = reset
+		logger.Warnf("rate limit on route %s exceeded, retry after: %ss", endpoint.URL, retryAfterHeader)
+		return time.Time{}, nil
+	}
+
+	// if we don't have a bucket header, we can't update anything
+	if bucketHeader == "" {
+		return time.Time{}, nil
 	}
 
+	b.ID = bucketHeader
+
 	if limitHeader != "" {
 		limit, err := strconv.Atoi(limitHeader)
 		if err != nil {
-			return fmt.Errorf("invalid limit %s: %s", limitHeader, err)
+			return time.Time{}, fmt.Errorf("invalid limit %s: %s", limitHeader, err)
 		}
 		b.Limit = limit
 	}
@@ -251,7 +274,7 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 	if remainingHeader != "" {
 		remaining, err := strconv.Atoi(remainingHeader)
 		if err != nil {
-			return fmt.Errorf("invalid remaining %s: %s", remainingHeader, err)
+			return time.Time{}, fmt.Errorf("invalid remaining %s: %s", remainingHeader, err)
 		}
 		b.Remaining = remaining
 	}
@@ -260,28 +283,25 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 	if resetAfterHeader != "" {
 		resetAfter, err := strconv.ParseFloat(resetAfterHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid reset after %s: %s", resetAfterHeader, err)
+			return time.Time{}, fmt.Errorf("invalid reset after %s: %s", resetAfterHeader, err)
 		}
 
 		b.Reset = time.Now().Add(time.Duration(resetAfter) * time.Second)
 	} else if resetHeader != "" {
 		reset, err := strconv.ParseFloat(resetHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid reset %s: %s", resetHeader, err)
+			return time.Time{}, fmt.Errorf("invalid reset %s: %s", resetHeader, err)
 		}
 
 		sec := int64(reset)
 		b.Reset = time.Unix(sec, int64((reset-float64(sec))*float64(time.Second)))
 	} else {
-		return fmt.Errorf("no reset or reset after header found in response")
+		return time.Time{}, fmt.Errorf("no reset or reset after header found in response")
 	}
-	return nil
+	return time.Time{}, nil
 }
 
 type bucket struct {
-	mu        csync.Mutex
-	ID        string
-	Reset     time.Time
-	Remaining int
-	Limit     int
+	mu csync.Mutex
+	RateLimitBucket
 }
@@ -0,0 +1,245 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var _ RateLimiter = (*sharedRateLimiterImpl)(nil)
+
+// RateLimitStore is used by the shared RateLimiter to coordinate rate limits between multiple processes using the same bot token.
+// It can be implemented on top of an external store like Redis. Implementations must be thread safe.
+type RateLimitStore interface {
+	// Lock acquires the lock with the given key. It blocks until the lock is acquired or the context is done.
+	// The lock is released automatically after the ttl in case the returned unlock func is never called.
+	Lock(ctx context.Context, key string, ttl time.Duration) (unlock func(), err error)
+
+	// Bucket returns the RateLimitBucket stored with the given key and a bool whether it was found or not.
+	Bucket(key string) (RateLimitBucket, bool, error)
+
+	// SetBucket stores the given RateLimitBucket with the given key. It may be removed once its reset time has passed.
+	SetBucket(key string, bucket RateLimitBucket) error
+
+	// BucketHash returns the discord bucket hash stored for the given route key and a bool whether it was found or not.
+	BucketHash(key string) (string, bool, error)
+
+	// SetBucketHash stores the discord bucket hash of the route with the given key.
+	SetBucketHash(key string, hash string) error
+
+	// Incr increments the counter with the given key and returns the new count and when the counter resets.
+	// The counter is reset to 0 after the given window passed since the first increment.
+	Incr(key string, window time.Duration) (int, time.Time, error)
+
+	// Clear removes all locks, buckets and counters.
+	Clear() error
+}
+
+// NewSharedRateLimiter returns a new RateLimiter which keeps its state in the given RateLimitStore.
+// Multiple clients using the same bot token & RateLimitStore coordinate their requests, including the global rate limit.
+func NewSharedRateLimiter(store RateLimitStore, opts ...SharedRateLimiterConfigOpt) RateLimiter {
+	config := DefaultSharedRateLimiterConfig()
+	config.Apply(opts)
+
+	return &sharedRateLimiterImpl{
+		config: *config,
+		store:  store,
+		locks:  map[*CompiledEndpoint]sharedLock{},
+	}
+}
+
+type sharedLock struct {
+	key    string
+	unlock func()
+}
+
+type sharedRateLimiterImpl struct {
+	config SharedRateLimiterConfig
+	store  RateLimitStore
+
+	// request -> lock held by this process. Keyed by request so a lock re-acquired after its ttl by another request of this process doesn't overwrite it.
+	locks   map[*CompiledEndpoint]sharedLock
+	locksMu sync.Mutex
+	pending sync.WaitGroup
+}
+
+func (l *sharedRateLimiterImpl) MaxRetries() int {
+	return l.config.MaxRetries
+}
+
+func (l *sharedRateLimiterImpl) Close(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		l.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-ctx.Done():
+	case <-done:
+	}
+}
+
+func (l *sharedRateLimiterImpl) Reset() {
+	if err := l.store.Clear(); err != nil {
+		l.config.Logger.Errorf("failed to clear rate limit store: %s", err)
+	}
+}
+
+func (l *sharedRateLimiterImpl) key(parts ...string) string {
+	key := l.config.KeyPrefix
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return key
+}
+
+func (l *sharedRateLimiterImpl) routeKey(endpoint *CompiledEndpoint) string {
+	return l.key("route", endpoint.Endpoint.Method+"+"+endpoint.Endpoint.Route)
+}
+
+// bucketKey returns the key of the bucket the given endpoint belongs to.
+// Once discord told us the bucket hash of a route, routes sharing the same bucket also share the same key.
+func (l *sharedRateLimiterImpl) bucketKey(endpoint *CompiledEndpoint) (string, error) {
+	hash, ok, err := l.store.BucketHash(l.routeKey(endpoint))
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		hash = endpoint.Endpoint.Method + "+" + endpoint.Endpoint.Route
+	}
+	return l.hashKey(hash, endpoint), nil
+}
+
+func (l *sharedRateLimiterImpl) hashKey(hash string, endpoint *CompiledEndpoint) string {
+	if endpoint.MajorParams != "" {
+		hash += "+" + endpoint.MajorParams
+	}
+	return l.key("bucket", hash)
+}
+
+func (l *sharedRateLimiterImpl) WaitBucket(ctx context.Context, endpoint *CompiledEndpoint) error {
+	key, err := l.bucketKey(endpoint)
+	if err != nil {
+		return err
+	}
+	l.config.Logger.Tracef("locking shared rest bucket, Key: %s", key)
+	unlock, err := l.store.Lock(ctx, key, l.config.LockTTL)
+	if err != nil {
+		return err
+	}
+	l.pending.Add(1)
+	l.locksMu.Lock()
+	l.locks[endpoint] = sharedLock{key: key, unlock: unlock}
+	l.locksMu.Unlock()
+
+	if err = l.wait(ctx, key); err != nil {
+		l.unlock(endpoint)
+		return err
+	}
+	return nil
+}
+
+func (l *sharedRateLimiterImpl) wait(ctx context.Context, key string) error {
+	for {
+		now := time.Now()
+		var until time.Time
+
+		b, ok, err := l.store.Bucket(key)
+		if err != nil {
+			return err
+		}
+		if ok && b.Remaining == 0 && b.Reset.After(now) {
+			until = b.Reset
+		}
+
+		global, ok, err := l.store.Bucket(l.key("global"))
+		if err != nil {
+			return err
+		}
+		if ok && global.Reset.After(until) {
+			until = global.Reset
+		}
+
+		if !until.After(now) && l.config.GlobalLimit > 0 {
+			var count int
+			if count, until, err = l.store.Incr(l.key("global", "count"), time.Second); err != nil {
+				return err
+			}
+			if count <= l.config.GlobalLimit {
+				return nil
+			}
+		}
+		if !until.After(now) {
+			return nil
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+			return context.DeadlineExceeded
+		}
+		timer := time.NewTimer(until.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *sharedRateLimiterImpl) unlock(endpoint *CompiledEndpoint) {
+	l.locksMu.Lock()
+	lock, ok := l.locks[endpoint]
+	delete(l.locks, endpoint)
+	l.locksMu.Unlock()
+	if ok {
+		lock.unlock()
+		l.pending.Done()
+	}
+}
+
+func (l *sharedRateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Response) error {
+	l.locksMu.Lock()
+	lock, ok := l.locks[endpoint]
+	l.locksMu.Unlock()
+	if !ok {
+		return nil
+	}
+	defer func() {
+		l.config.Logger.Tracef("unlocking shared rest bucket, Key: %s", lock.key)
+		l.unlock(endpoint)
+	}()
+
+	b, ok, err := l.store.Bucket(lock.key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		b = RateLimitBucket{
+			Remaining: 1,
+			// we don't know the limit yet
+			Limit: -1,
+		}
+	}
+
+	globalReset, err := applyRateLimitHeaders(l.config.Logger, endpoint, rs, &b)
+	if !globalReset.IsZero() {
+		if sErr := l.store.SetBucket(l.key("global"), RateLimitBucket{ID: "global", Reset: globalReset}); sErr != nil {
+			return sErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	key := lock.key
+	if b.ID != "" {
+		// remember the discord bucket hash so all routes of this bucket share the same key from now on
+		if key = l.hashKey(b.ID, endpoint); key != lock.key {
+			if err = l.store.SetBucketHash(l.routeKey(endpoint), b.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return l.store.SetBucket(key, b)
+}
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultSharedRateLimiterConfig is the configuration which is used by default.
+func DefaultSharedRateLimiterConfig() *SharedRateLimiterConfig {
+	return &SharedRateLimiterConfig{
+		Logger:      log.Default(),
+		MaxRetries:  10,
+		GlobalLimit: 50,
+		LockTTL:     time.Second * 30,
+		KeyPrefix:   "disgo:rest",
+	}
+}
+
+// SharedRateLimiterConfig is the configuration for the shared rate limiter.
+type SharedRateLimiterConfig struct {
+	Logger      log.Logger
+	MaxRetries  int
+	GlobalLimit int
+	LockTTL     time.Duration
+	KeyPrefix   string
+}
+
+// SharedRateLimiterConfigOpt can be used to supply optional parameters to NewSharedRateLimiter.
+type SharedRateLimiterConfigOpt func(config *SharedRateLimiterConfig)
+
+// Apply applies the given SharedRateLimiterConfigOpt(s) to the SharedRateLimiterConfig.
+func (c *SharedRateLimiterConfig) Apply(opts []SharedRateLimiterConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithSharedRateLimiterLogger applies a custom logger to the shared rate limiter.
+func WithSharedRateLimiterLogger(logger log.Logger) SharedRateLimiterConfigOpt {
+	return func(config *SharedRateLimiterConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithSharedMaxRetries tells the shared rate limiter to retry the request up to the specified number of times if it encounters a 429 response.
+func WithSharedMaxRetries(maxRetries int) SharedRateLimiterConfigOpt {
+	return func(config *SharedRateLimiterConfig) {
+		config.MaxRetries = maxRetries
+	}
+}
+
+// WithGlobalLimit sets how many requests per second all clients sharing the RateLimitStore are allowed to make together.
+// Discord allows 50 requests per second per bot token. A value of 0 disables proactive global rate limiting.
+func WithGlobalLimit(globalLimit int) SharedRateLimiterConfigOpt {
+	return func(config *SharedRateLimiterConfig) {
+		config.GlobalLimit = globalLimit
+	}
+}
+
+// WithLockTTL sets after which duration a bucket lock is released automatically in case the holding process dies.
+// This should be longer than your longest request takes.
+func WithLockTTL(lockTTL time.Duration) SharedRateLimiterConfigOpt {
+	return func(config *SharedRateLimiterConfig) {
+		config.LockTTL = lockTTL
+	}
+}
+
+// WithRateLimitKeyPrefix sets the prefix for all keys in the RateLimitStore. Clients using different bot tokens must use different prefixes.
+func WithRateLimitKeyPrefix(keyPrefix string) SharedRateLimiterConfigOpt {
+	return func(config *SharedRateLimiterConfig) {
+		config.KeyPrefix = keyPrefix
+	}
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitResponse(statusCode int, headers map[string]string) *http.Response {
+	rs := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+	}
+	rs.Header.Set("via", "1.1 google")
+	for k, v := range headers {
+		rs.Header.Set(k, v)
+	}
+	return rs
+}
+
+func waitShortly(l RateLimiter, endpoint *CompiledEndpoint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	return l.WaitBucket(ctx, endpoint)
+}
+
+func TestSharedRateLimiterGlobal(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	l1 := NewSharedRateLimiter(store, WithGlobalLimit(0))
+	l2 := NewSharedRateLimiter(store, WithGlobalLimit(0))
+
+	endpoint := NewEndpoint(http.MethodGet, "/users/@me").Compile(nil)
+	assert.NoError(t, l1.WaitBucket(context.Background(), endpoint))
+	// global rate limits don't include a bucket header
+	assert.NoError(t, l1.UnlockBucket(endpoint, newRateLimitResponse(http.StatusTooManyRequests, map[string]string{
+		"X-RateLimit-Global": "true",
+		"Retry-After":        "10",
+	})))
+
+	other := NewEndpoint(http.MethodGet, "/gateway/bot").Compile(nil)
+	assert.ErrorIs(t, waitShortly(l2, other), context.DeadlineExceeded)
+}
+
+func TestSharedRateLimiterBucketHash(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	l := NewSharedRateLimiter(store, WithGlobalLimit(0))
+
+	first := NewEndpoint(http.MethodPatch, "/channels/{channel.id}").Compile(nil, 1)
+	assert.NoError(t, l.WaitBucket(context.Background(), first))
+	assert.NoError(t, l.UnlockBucket(first, newRateLimitResponse(http.StatusOK, map[string]string{
+		"X-RateLimit-Bucket":      "abc",
+		"X-RateLimit-Limit":       "2",
+		"X-RateLimit-Remaining":   "1",
+		"X-RateLimit-Reset-After": "10",
+	})))
+
+	second := NewEndpoint(http.MethodDelete, "/channels/{channel.id}").Compile(nil, 1)
+	assert.NoError(t, l.WaitBucket(context.Background(), second))
+	assert.NoError(t, l.UnlockBucket(second, newRateLimitResponse(http.StatusOK, map[string]string{
+		"X-RateLimit-Bucket":      "abc",
+		"X-RateLimit-Limit":       "2",
+		"X-RateLimit-Remaining":   "0",
+		"X-RateLimit-Reset-After": "10",
+	})))
+
+	// both routes share the exhausted bucket "abc"
+	again := NewEndpoint(http.MethodPatch, "/channels/{channel.id}").Compile(nil, 1)
+	assert.ErrorIs(t, waitShortly(l, again), context.DeadlineExceeded)
+
+	// but not with other major parameters
+	otherChannel := NewEndpoint(http.MethodPatch, "/channels/{channel.id}").Compile(nil, 2)
+	assert.NoError(t, waitShortly(l, otherChannel))
+	assert.NoError(t, l.UnlockBucket(otherChannel, nil))
+}
+
+func TestSharedRateLimiterCloseAfterLockTTL(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	l := NewSharedRateLimiter(store, WithGlobalLimit(0), WithLockTTL(10*time.Millisecond))
+
+	first := NewEndpoint(http.MethodGet, "/users/@me").Compile(nil)
+	assert.NoError(t, l.WaitBucket(context.Background(), first))
+
+	// the lock of the first request expires and is acquired by the second one
+	second := NewEndpoint(http.MethodGet, "/users/@me").Compile(nil)
+	assert.NoError(t, l.WaitBucket(context.Background(), second))
+
+	assert.NoError(t, l.UnlockBucket(second, nil))
+	assert.NoError(t, l.UnlockBucket(first, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	l.Close(ctx)
+	assert.NoError(t, ctx.Err())
+}
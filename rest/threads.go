@@ -26,10 +26,15 @@ type Threads interface {
 	GetThreadMember(threadID snowflake.ID, userID snowflake.ID, withMember bool, opts ...RequestOpt) (threadMember *discord.ThreadMember, err error)
 	GetThreadMembers(threadID snowflake.ID, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error)
 	GetThreadMembersPage(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) ThreadMemberPage
+	GetThreadMembersPaginator(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.ThreadMember]
 
 	GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
+
+	GetPublicArchivedThreadsPaginator(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) *Paginator[discord.GuildThread]
+	GetPrivateArchivedThreadsPaginator(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) *Paginator[discord.GuildThread]
+	GetJoinedPrivateArchivedThreadsPaginator(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) *Paginator[discord.GuildThread]
 }
 
 type threadImpl struct {
@@ -97,6 +102,22 @@ func (s *threadImpl) GetThreadMembersPage(threadID snowflake.ID, startID snowfla
 	}
 }
 
+// GetThreadMembersPaginator only supports PageDirectionAfter as discord doesn't support fetching thread members before a user.
+func (s *threadImpl) GetThreadMembersPaginator(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) *Paginator[discord.ThreadMember] {
+	return newIDPaginator(func(_ snowflake.ID, after snowflake.ID) ([]discord.ThreadMember, error) {
+		queryValues := discord.QueryValues{
+			"with_member": true,
+			"after":       after,
+		}
+		if limit != 0 {
+			queryValues["limit"] = limit
+		}
+		return s.getThreadMembers(threadID, queryValues, opts...)
+	}, func(threadMember discord.ThreadMember) snowflake.ID {
+		return threadMember.UserID
+	}, PageDirectionAfter, startID, limit)
+}
+
 func (s *threadImpl) GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
 	queryValues := discord.QueryValues{}
 	if !before.IsZero() {
@@ -133,6 +154,44 @@ func (s *threadImpl) GetJoinedPrivateArchivedThreads(channelID snowflake.ID, bef
 	return
 }
 
+func (s *threadImpl) GetPublicArchivedThreadsPaginator(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) *Paginator[discord.GuildThread] {
+	return newArchivedThreadsPaginator(func(before time.Time) (*discord.GetThreads, error) {
+		return s.GetPublicArchivedThreads(channelID, before, limit, opts...)
+	}, before)
+}
+
+func (s *threadImpl) GetPrivateArchivedThreadsPaginator(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) *Paginator[discord.GuildThread] {
+	return newArchivedThreadsPaginator(func(before time.Time) (*discord.GetThreads, error) {
+		return s.GetPrivateArchivedThreads(channelID, before, limit, opts...)
+	}, before)
+}
+
+func (s *threadImpl) GetJoinedPrivateArchivedThreadsPaginator(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) *Paginator[discord.GuildThread] {
+	return newArchivedThreadsPaginator(func(before time.Time) (*discord.GetThreads, error) {
+		return s.GetJoinedPrivateArchivedThreads(channelID, before, limit, opts...)
+	}, before)
+}
+
+// newArchivedThreadsPaginator returns a Paginator which traverses archived threads by their archive timestamp, starting with the most recently archived.
+func newArchivedThreadsPaginator(fetchFunc func(before time.Time) (*discord.GetThreads, error), before time.Time) *Paginator[discord.GuildThread] {
+	paginator := newPaginator(func(cursor *discord.GuildThread) ([]discord.GuildThread, bool, error) {
+		if cursor != nil {
+			before = cursor.ThreadMetadata.ArchiveTimestamp
+		}
+		threads, err := fetchFunc(before)
+		if err != nil || threads == nil {
+			return nil, false, err
+		}
+		return threads.Threads, threads.HasMore, nil
+	}, func(thread discord.GuildThread) snowflake.ID {
+		return thread.ID()
+	}, PageDirectionBefore)
+	paginator.timeFunc = func(thread discord.GuildThread) time.Time {
+		return thread.ThreadMetadata.ArchiveTimestamp
+	}
+	return paginator
+}
+
 func (s *threadImpl) getThreadMembers(threadID snowflake.ID, queryValues discord.QueryValues, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error) {
 	err = s.client.Do(GetThreadMembers.Compile(queryValues, threadID), nil, &threadMembers, opts...)
 	return
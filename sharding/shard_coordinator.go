@@ -0,0 +1,198 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// Assignment is the set of shards a node of a cluster should run.
+type Assignment struct {
+	ShardCount int
+	ShardIDs   []int
+}
+
+// Coordinator assigns the shards of a cluster to its nodes and shares the identify rate limit between them.
+// A ShardManager configured with WithCoordinator joins the cluster on Open and leaves it on Close.
+// Shards which move to another node while the cluster is rebalanced keep running on their previous node until the new node hands them over
+// via HandoverShard and resumes them.
+type Coordinator interface {
+	// Join adds the node to the cluster and rebalances the shards.
+	// The HandoverSource is used to hand over the shards of the node which moved to another node.
+	// The returned channel receives the current Assignment of the node every time the cluster is rebalanced and is closed once the node left.
+	Join(ctx context.Context, nodeID string, source HandoverSource) (<-chan Assignment, error)
+
+	// HandoverShard hands over the given shard from the node which ran it before it moved to the calling node.
+	// It returns discord.ErrShardNotFound if the shard didn't run on another node.
+	HandoverSource
+
+	// Leave removes the node from the cluster and rebalances its shards to the remaining nodes.
+	Leave(ctx context.Context, nodeID string) error
+
+	// RateLimiter returns the RateLimiter shared by all nodes, so max_concurrency is respected across the cluster.
+	RateLimiter() RateLimiter
+}
+
+var _ Coordinator = (*localCoordinator)(nil)
+
+// NewLocalCoordinator returns a Coordinator for ShardManager(s) in the same process.
+// The shards are split evenly between the nodes. Shards only move if the balance requires it.
+func NewLocalCoordinator(shardCount int, rateLimiter RateLimiter) Coordinator {
+	return &localCoordinator{
+		shardCount:  shardCount,
+		rateLimiter: rateLimiter,
+		nodes:       map[string]chan Assignment{},
+		sources:     map[string]HandoverSource{},
+		assignments: map[string][]int{},
+		previous:    map[int]string{},
+	}
+}
+
+type localCoordinator struct {
+	shardCount  int
+	rateLimiter RateLimiter
+
+	mu sync.Mutex
+	// node id -> channel to send new assignments to
+	nodes map[string]chan Assignment
+	// node id -> source to hand over shards of the node
+	sources map[string]HandoverSource
+	// node id -> assigned shard ids
+	assignments map[string][]int
+	// shard id -> node id which still runs the moved shard
+	previous map[int]string
+}
+
+func (c *localCoordinator) Join(_ context.Context, nodeID string, source HandoverSource) (<-chan Assignment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.nodes[nodeID]; ok {
+		return nil, fmt.Errorf("node %s already joined the cluster", nodeID)
+	}
+	assignments := make(chan Assignment, 1)
+	c.nodes[nodeID] = assignments
+	c.sources[nodeID] = source
+	c.rebalance()
+	return assignments, nil
+}
+
+func (c *localCoordinator) Leave(_ context.Context, nodeID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assignments, ok := c.nodes[nodeID]
+	if !ok {
+		return fmt.Errorf("node %s is not part of the cluster", nodeID)
+	}
+	close(assignments)
+	delete(c.nodes, nodeID)
+	delete(c.sources, nodeID)
+	delete(c.assignments, nodeID)
+	// the node closed its shards, so they can't be handed over anymore
+	for shardID, previousNodeID := range c.previous {
+		if previousNodeID == nodeID {
+			delete(c.previous, shardID)
+		}
+	}
+	c.rebalance()
+	return nil
+}
+
+func (c *localCoordinator) HandoverShard(ctx context.Context, shardID int) (*gateway.Session, error) {
+	c.mu.Lock()
+	nodeID, ok := c.previous[shardID]
+	source := c.sources[nodeID]
+	delete(c.previous, shardID)
+	c.mu.Unlock()
+	if !ok || source == nil {
+		return nil, discord.ErrShardNotFound
+	}
+	// don't hold the lock while the node hands over the shard as it might apply a new assignment in the meantime
+	return source.HandoverShard(ctx, shardID)
+}
+
+func (c *localCoordinator) RateLimiter() RateLimiter {
+	return c.rateLimiter
+}
+
+// rebalance splits the shards evenly between all nodes and sends the new assignments. c.mu must be held.
+func (c *localCoordinator) rebalance() {
+	if len(c.nodes) == 0 {
+		return
+	}
+	nodeIDs := make([]string, 0, len(c.nodes))
+	for nodeID := range c.nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	targets := make(map[string]int, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		targets[nodeID] = c.shardCount / len(nodeIDs)
+		if i < c.shardCount%len(nodeIDs) {
+			targets[nodeID]++
+		}
+	}
+
+	// keep as many shards as possible on their current node
+	assigned := make(map[int]struct{}, c.shardCount)
+	assignments := make(map[string][]int, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		shardIDs := c.assignments[nodeID]
+		if len(shardIDs) > targets[nodeID] {
+			shardIDs = shardIDs[:targets[nodeID]]
+		}
+		for _, shardID := range shardIDs {
+			assigned[shardID] = struct{}{}
+		}
+		assignments[nodeID] = append([]int{}, shardIDs...)
+	}
+
+	// remember where moved shards still run, so they can be handed over
+	owners := make(map[int]string, c.shardCount)
+	for nodeID, shardIDs := range c.assignments {
+		for _, shardID := range shardIDs {
+			owners[shardID] = nodeID
+		}
+	}
+
+	shardID := 0
+	for _, nodeID := range nodeIDs {
+		for len(assignments[nodeID]) < targets[nodeID] {
+			if _, ok := assigned[shardID]; !ok {
+				assignments[nodeID] = append(assignments[nodeID], shardID)
+			}
+			shardID++
+		}
+		sort.Ints(assignments[nodeID])
+		for _, assignedShardID := range assignments[nodeID] {
+			if previousNodeID, ok := c.previous[assignedShardID]; ok {
+				// the shard still runs on the node it ran on before it moved the first time
+				if previousNodeID == nodeID {
+					delete(c.previous, assignedShardID)
+				}
+				continue
+			}
+			if owner, ok := owners[assignedShardID]; ok && owner != nodeID {
+				c.previous[assignedShardID] = owner
+			}
+		}
+	}
+	c.assignments = assignments
+
+	for _, nodeID := range nodeIDs {
+		ch := c.nodes[nodeID]
+		// replace an assignment which wasn't received yet, it is outdated anyway
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- Assignment{
+			ShardCount: c.shardCount,
+			ShardIDs:   append([]int{}, assignments[nodeID]...),
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package sharding
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func shardIDsOf(manager ShardManager) []int {
+	var shardIDs []int
+	for shardID := range manager.Shards() {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Ints(shardIDs)
+	return shardIDs
+}
+
+func TestClusterRebalance(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		readys = map[int]int{}
+	)
+	coordinator := NewLocalCoordinator(4, testRateLimiter{})
+	newNode := func(nodeID string) ShardManager {
+		return New("token", func(eventType gateway.EventType, _ int, shardID int, _ gateway.EventData) {
+			if eventType == gateway.EventTypeReady {
+				mu.Lock()
+				defer mu.Unlock()
+				readys[shardID]++
+			}
+		},
+			WithGatewayCreateFunc(newTestGateway),
+			WithCoordinator(coordinator, nodeID),
+		)
+	}
+
+	nodeA := newNode("a")
+	nodeA.Open(context.Background())
+	defer nodeA.Close(context.Background())
+	assert.Equal(t, []int{0, 1, 2, 3}, shardIDsOf(nodeA))
+	// shards can only be handed over once they have a session
+	assert.Eventually(t, func() bool {
+		for _, shard := range nodeA.Shards() {
+			if shard.SessionID() == nil {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	nodeB := newNode("b")
+	nodeB.Open(context.Background())
+	assert.Equal(t, []int{2, 3}, shardIDsOf(nodeB))
+	assert.Equal(t, []int{0, 1}, shardIDsOf(nodeA))
+
+	// the moved shards were resumed instead of identifying again
+	mu.Lock()
+	assert.Equal(t, map[int]int{0: 1, 1: 1, 2: 1, 3: 1}, readys)
+	mu.Unlock()
+
+	nodeB.Close(context.Background())
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]int{0, 1, 2, 3}, shardIDsOf(nodeA))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClusterApplyAssignmentAfterClose(t *testing.T) {
+	manager := New("token", func(gateway.EventType, int, int, gateway.EventData) {},
+		WithGatewayCreateFunc(newTestGateway),
+		WithCoordinator(NewLocalCoordinator(2, testRateLimiter{}), "a"),
+	)
+	manager.Open(context.Background())
+	manager.Close(context.Background())
+
+	manager.(*shardManagerImpl).applyAssignment(context.Background(), Assignment{ShardCount: 2, ShardIDs: []int{0, 1}})
+	assert.Empty(t, manager.Shards())
+}
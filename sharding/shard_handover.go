@@ -0,0 +1,256 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// HandoverSource gives up shards, so they can be resumed by another ShardManager without identifying again.
+// ShardManager implements it for handovers in the same process. Use ServeHandover and NewHandoverClient to hand over shards between processes.
+type HandoverSource interface {
+	// HandoverShard stops dispatching events of the given shard, closes it without invalidating its session and returns the session.
+	// Events received after the returned sequence are replayed by discord once the session is resumed.
+	HandoverShard(ctx context.Context, shardID int) (*gateway.Session, error)
+}
+
+type handoverRequest struct {
+	ShardID int `json:"shard_id"`
+}
+
+type handoverResponse struct {
+	Session *gateway.Session `json:"session,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// ServeHandover accepts handover requests sent by a HandoverSource returned by NewHandoverClient on the given net.Listener and passes them to the given HandoverSource.
+// It blocks until the context is done or the net.Listener is closed.
+//
+//	listener, err := net.Listen("unix", "/tmp/bot-handover.sock")
+//	go sharding.ServeHandover(ctx, listener, client.ShardManager())
+func ServeHandover(ctx context.Context, listener net.Listener, source HandoverSource) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			serveHandoverConn(ctx, conn, source)
+		}()
+	}
+}
+
+func serveHandoverConn(ctx context.Context, conn net.Conn, source HandoverSource) {
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		var rq handoverRequest
+		if err := decoder.Decode(&rq); err != nil {
+			return
+		}
+
+		var rs handoverResponse
+		session, err := source.HandoverShard(ctx, rq.ShardID)
+		if err != nil {
+			rs.Error = err.Error()
+		} else {
+			rs.Session = session
+		}
+		if err = encoder.Encode(rs); err != nil {
+			return
+		}
+	}
+}
+
+// NewHandoverClient returns a HandoverSource which requests shards from a ServeHandover running at the given network address, for example a unix socket.
+func NewHandoverClient(network string, address string) HandoverSource {
+	return &handoverClient{
+		network: network,
+		address: address,
+	}
+}
+
+type handoverClient struct {
+	network string
+	address string
+	dialer  net.Dialer
+}
+
+func (c *handoverClient) HandoverShard(ctx context.Context, shardID int) (*gateway.Session, error) {
+	conn, err := c.dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if err = json.NewEncoder(conn).Encode(handoverRequest{ShardID: shardID}); err != nil {
+		return nil, err
+	}
+	var rs handoverResponse
+	if err = json.NewDecoder(conn).Decode(&rs); err != nil {
+		return nil, err
+	}
+	if rs.Error != "" {
+		return nil, fmt.Errorf("failed to hand over shard %d: %s", shardID, rs.Error)
+	}
+	return rs.Session, nil
+}
+
+// shardState tracks the session of a shard as seen by the dispatched events, so no event is dispatched by both processes of a handover.
+// While re-sharding, the events of the new shards are buffered until they replace the old shards.
+// The wrapped gateway.EventHandlerFunc is never called while holding mu, so it can use the ShardManager.
+type shardState struct {
+	mu         sync.Mutex
+	handedOver bool
+	session    gateway.Session
+	// rawSequence is the sequence of the last raw event whose typed event was not dispatched yet
+	rawSequence int
+	buffering   bool
+	buffer      []func()
+	// bufferSize is the amount of events buffered before non-essential events are dropped
+	bufferSize int
+	dropped    int
+}
+
+// load initializes the session from the given shard before it is opened.
+func (s *shardState) load(shard gateway.Gateway) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session.ShardCount = shard.ShardCount()
+	if sessionID := shard.SessionID(); sessionID != nil {
+		s.session.ID = *sessionID
+	}
+	if resumeURL := shard.ResumeURL(); resumeURL != nil {
+		s.session.ResumeURL = *resumeURL
+	}
+	if sequence := shard.LastSequenceReceived(); sequence != nil {
+		s.session.Sequence = *sequence
+	}
+}
+
+// eventHandlerFunc wraps the given gateway.EventHandlerFunc and drops all events once the shard was handed over.
+func (s *shardState) eventHandlerFunc(eventHandlerFunc gateway.EventHandlerFunc) gateway.EventHandlerFunc {
+	return func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		if !s.track(eventType, sequenceNumber, event) {
+			return
+		}
+		dispatch := func() {
+			eventHandlerFunc(eventType, sequenceNumber, shardID, event)
+		}
+		s.mu.Lock()
+		if s.buffering {
+			if len(s.buffer) >= s.bufferSize && eventType != gateway.EventTypeReady && eventType != gateway.EventTypeGuildCreate {
+				s.dropped++
+			} else {
+				s.buffer = append(s.buffer, dispatch)
+			}
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+		dispatch()
+	}
+}
+
+// track updates the session with the given event before it is dispatched and reports whether it should be dispatched.
+// The sequence is updated before dispatching, so a handover in the meantime resumes after this event.
+func (s *shardState) track(eventType gateway.EventType, sequenceNumber int, event gateway.EventData) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.handedOver {
+		// the raw event was dispatched before the handover, so the typed event with the same sequence is still ours
+		if eventType != gateway.EventTypeRaw && sequenceNumber > 0 && sequenceNumber == s.rawSequence {
+			s.rawSequence = 0
+			return true
+		}
+		return false
+	}
+	if readyEvent, ok := event.(gateway.EventReady); ok {
+		s.session.ID = readyEvent.SessionID
+		s.session.ResumeURL = readyEvent.ResumeGatewayURL
+	}
+	if sequenceNumber > 0 {
+		// raw events are dispatched right before the typed event with the same sequence
+		if eventType == gateway.EventTypeRaw {
+			s.rawSequence = sequenceNumber
+		} else {
+			s.rawSequence = 0
+		}
+		s.session.Sequence = sequenceNumber
+	}
+	return true
+}
+
+// stop drops all further events.
+func (s *shardState) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handedOver = true
+	s.buffer = nil
+}
+
+// startBuffering holds back up to bufferSize events until flush is called. See WithReshardBufferSize.
+func (s *shardState) startBuffering(bufferSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buffering = true
+	s.bufferSize = bufferSize
+}
+
+// flush dispatches all buffered events, stops buffering and returns the amount of dropped events.
+// Events received while flushing are buffered as well, so they are dispatched in order.
+func (s *shardState) flush() int {
+	for {
+		s.mu.Lock()
+		buffer := s.buffer
+		s.buffer = nil
+		if len(buffer) == 0 {
+			s.buffering = false
+			dropped := s.dropped
+			s.dropped = 0
+			s.mu.Unlock()
+			return dropped
+		}
+		s.mu.Unlock()
+
+		for _, dispatch := range buffer {
+			dispatch()
+		}
+	}
+}
+
+// handover stops dispatching events and returns the session to resume.
+func (s *shardState) handover() (gateway.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.session.ID == "" {
+		return gateway.Session{}, discord.ErrShardNoSession
+	}
+	s.handedOver = true
+	return s.session, nil
+}
@@ -0,0 +1,214 @@
+package sharding
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+const testEventCount = 200
+
+var _ gateway.Gateway = (*testGateway)(nil)
+
+// testGateway simulates a shard which receives testEventCount events and replays all events after its sequence when resuming.
+type testGateway struct {
+	config           gateway.Config
+	eventHandlerFunc gateway.EventHandlerFunc
+
+	mu        sync.Mutex
+	closeCode int
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newTestGateway(_ string, eventHandlerFunc gateway.EventHandlerFunc, _ gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+	config := gateway.DefaultConfig()
+	config.Apply(opts)
+	return &testGateway{
+		config:           *config,
+		eventHandlerFunc: eventHandlerFunc,
+	}
+}
+
+func (g *testGateway) ShardID() int    { return g.config.ShardID }
+func (g *testGateway) ShardCount() int { return g.config.ShardCount }
+
+func (g *testGateway) SessionID() *string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.config.SessionID
+}
+
+func (g *testGateway) ResumeURL() *string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.config.ResumeURL
+}
+
+func (g *testGateway) LastSequenceReceived() *int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.config.LastSequenceReceived
+}
+
+func (g *testGateway) Intents() gateway.Intents { return g.config.Intents }
+
+func (g *testGateway) Open(_ context.Context) error {
+	g.done = make(chan struct{})
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		sequence, ready := 0, false
+		if seq := g.LastSequenceReceived(); seq != nil {
+			sequence = *seq
+		}
+		for sequence < testEventCount {
+			select {
+			case <-g.done:
+				return
+			case <-time.After(100 * time.Microsecond):
+			}
+			sequence++
+
+			var (
+				eventType gateway.EventType = "MESSAGE_CREATE"
+				event     gateway.EventData = gateway.EventHeartbeatAck{}
+			)
+			g.mu.Lock()
+			g.config.LastSequenceReceived = &sequence
+			if g.config.SessionID == nil {
+				sessionID, resumeURL := "session", "wss://resume"
+				g.config.SessionID, g.config.ResumeURL = &sessionID, &resumeURL
+				eventType, event = gateway.EventTypeReady, gateway.EventReady{SessionID: sessionID, ResumeGatewayURL: resumeURL}
+			}
+			g.mu.Unlock()
+			g.eventHandlerFunc(eventType, sequence, g.config.ShardID, event)
+			if !ready && g.config.StatusChangeFunc != nil {
+				g.config.StatusChangeFunc(g, gateway.StatusChange{NewStatus: gateway.StatusReady, Reason: gateway.StatusChangeReasonReady})
+			}
+			ready = true
+		}
+	}()
+	return nil
+}
+
+func (g *testGateway) Close(ctx context.Context) {
+	g.CloseWithCode(ctx, 1000, "")
+}
+
+func (g *testGateway) CloseWithCode(_ context.Context, code int, _ string) {
+	g.mu.Lock()
+	g.closeCode = code
+	g.mu.Unlock()
+	close(g.done)
+	g.wg.Wait()
+}
+
+func (g *testGateway) Status() gateway.Status { return gateway.StatusReady }
+
+func (g *testGateway) Send(_ context.Context, _ gateway.Opcode, _ gateway.MessageData) error {
+	return nil
+}
+
+func (g *testGateway) Latency() time.Duration { return 0 }
+
+func (g *testGateway) Presence() *gateway.MessageDataPresenceUpdate { return nil }
+
+func TestShardHandover(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		sequences []int
+		received  = make(chan int, testEventCount)
+	)
+	eventHandlerFunc := func(_ gateway.EventType, sequenceNumber int, _ int, _ gateway.EventData) {
+		mu.Lock()
+		defer mu.Unlock()
+		sequences = append(sequences, sequenceNumber)
+		received <- sequenceNumber
+	}
+
+	oldManager := New("token", eventHandlerFunc, WithGatewayCreateFunc(newTestGateway), WithShardCount(1), WithShardIDs(0))
+	newManager := New("token", eventHandlerFunc, WithGatewayCreateFunc(newTestGateway), WithShardCount(1))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = ServeHandover(ctx, listener, oldManager)
+	}()
+
+	oldManager.Open(context.Background())
+	// hand over the shard while it is receiving events
+	for sequence := range received {
+		if sequence >= testEventCount/4 {
+			break
+		}
+	}
+	assert.NoError(t, newManager.TakeOverShards(context.Background(), NewHandoverClient("tcp", listener.Addr().String()), 0))
+	assert.Nil(t, oldManager.Shard(0))
+	assert.NotNil(t, newManager.Shard(0))
+
+	for sequence := range received {
+		if sequence == testEventCount {
+			break
+		}
+	}
+	newManager.Close(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := make([]int, testEventCount)
+	for i := range expected {
+		expected[i] = i + 1
+	}
+	assert.Equal(t, expected, sequences)
+}
+
+func TestShardStateHandoverFromEventHandler(t *testing.T) {
+	state := &shardState{}
+	var session gateway.Session
+	handlerFunc := state.eventHandlerFunc(func(_ gateway.EventType, _ int, _ int, _ gateway.EventData) {
+		// handing over from within an event handler must not deadlock
+		var err error
+		session, err = state.handover()
+		assert.NoError(t, err)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handlerFunc(gateway.EventTypeReady, 1, 0, gateway.EventReady{SessionID: "session"})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event handler deadlocked")
+	}
+	assert.Equal(t, gateway.Session{ID: "session", Sequence: 1}, session)
+}
+
+func TestShardStateHandoverBetweenRawEvent(t *testing.T) {
+	state := &shardState{session: gateway.Session{ID: "session"}}
+	var dispatched []gateway.EventType
+	handlerFunc := state.eventHandlerFunc(func(eventType gateway.EventType, _ int, _ int, _ gateway.EventData) {
+		dispatched = append(dispatched, eventType)
+	})
+
+	handlerFunc(gateway.EventTypeRaw, 5, 0, gateway.EventRaw{})
+	session, err := state.handover()
+	assert.NoError(t, err)
+	// the new process resumes after the raw event, so the typed event has to be dispatched by the old process
+	assert.Equal(t, 5, session.Sequence)
+	handlerFunc(gateway.EventTypeMessageCreate, 5, 0, gateway.EventMessageCreate{})
+	handlerFunc(gateway.EventTypeRaw, 6, 0, gateway.EventRaw{})
+	handlerFunc(gateway.EventTypeMessageCreate, 6, 0, gateway.EventMessageCreate{})
+
+	assert.Equal(t, []gateway.EventType{gateway.EventTypeRaw, gateway.EventTypeMessageCreate}, dispatched)
+}
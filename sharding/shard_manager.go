@@ -14,14 +14,39 @@ type ShardManager interface {
 	// Open opens all configured shards.
 	Open(ctx context.Context)
 	// Close closes all shards.
+	// This invalidates their sessions and deletes them from the gateway.SessionStore.
 	Close(ctx context.Context)
 
+	// CloseResumable closes all shards without invalidating their sessions, so they can be resumed from the gateway.SessionStore after a restart.
+	CloseResumable(ctx context.Context)
+
 	// OpenShard opens a specific shard.
 	OpenShard(ctx context.Context, shardID int) error
 
 	// CloseShard closes a specific shard.
 	CloseShard(ctx context.Context, shardID int)
 
+	// HandoverShard stops dispatching events of the given shard and closes it without invalidating its session.
+	// The returned gateway.Session can be passed to ResumeShard of another ShardManager. See HandoverSource for more information.
+	HandoverShard(ctx context.Context, shardID int) (*gateway.Session, error)
+
+	// ResumeShard opens the given shard by resuming the given gateway.Session handed over by another ShardManager.
+	ResumeShard(ctx context.Context, shardID int, session gateway.Session) error
+
+	// TakeOverShards hands over the given shards from the HandoverSource one by one and resumes them.
+	TakeOverShards(ctx context.Context, source HandoverSource, shardIDs ...int) error
+
+	// Reshard boots all shards for the given shard count in parallel while the current shards keep running.
+	// If the ShardManager only manages some shards, it only boots the shards id, id+oldShardCount, ... of its shards, which requires the shard count to be a multiple of the current one.
+	// Once all new shards are ready, they atomically replace the current shards which are closed afterwards.
+	// Events of the new shards are held back until then, see WithReshardBufferSize. Events received by both shard sets in the meantime might be dispatched twice.
+	// Identifies are batched by the RateLimiter according to max_concurrency. The re-sharding fails if the new shards aren't ready within WithReshardTimeout.
+	// It returns discord.ErrShardsCoordinated if the shards are assigned by a Coordinator.
+	Reshard(ctx context.Context, shardCount int) error
+
+	// ReshardRecommended re-shards to the shard count returned by the RecommendedShardCountFunc if it is higher than the current shard count.
+	ReshardRecommended(ctx context.Context) error
+
 	// ShardByGuildID returns the gateway.Gateway for the shard that contains the given guild.
 	ShardByGuildID(guildId snowflake.ID) gateway.Gateway
 
@@ -0,0 +1,134 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var _ HandoverSource = (*clusterHandoverSource)(nil)
+
+// clusterHandoverSource hands over the shards of a ShardManager which moved to another node of the cluster.
+type clusterHandoverSource struct {
+	m *shardManagerImpl
+}
+
+func (s *clusterHandoverSource) HandoverShard(ctx context.Context, shardID int) (*gateway.Session, error) {
+	session, err := s.m.HandoverShard(ctx, shardID)
+	if err != nil && !errors.Is(err, discord.ErrShardNotFound) {
+		// the other node identifies the shard instead, so make sure it doesn't run on both nodes
+		s.m.CloseShard(ctx, shardID)
+	}
+	return session, err
+}
+
+// openCluster joins the cluster, opens the shards of the first Assignment and applies all further assignments in the background.
+func (m *shardManagerImpl) openCluster(ctx context.Context) {
+	clusterCtx, cancel := context.WithCancel(context.Background())
+	m.shardsMu.Lock()
+	m.clusterCancel = cancel
+	m.shardsMu.Unlock()
+
+	m.config.Logger.Debugf("joining cluster as node %s...", m.config.NodeID)
+	assignments, err := m.config.Coordinator.Join(ctx, m.config.NodeID, &clusterHandoverSource{m: m})
+	if err != nil {
+		m.config.Logger.Errorf("failed to join cluster: %s", err)
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+		m.config.Logger.Errorf("failed to receive shard assignment: %s", ctx.Err())
+		return
+	case assignment, ok := <-assignments:
+		if !ok {
+			return
+		}
+		m.applyAssignment(ctx, assignment)
+	}
+
+	go m.watchAssignments(clusterCtx, assignments)
+}
+
+func (m *shardManagerImpl) watchAssignments(ctx context.Context, assignments <-chan Assignment) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case assignment, ok := <-assignments:
+			if !ok {
+				return
+			}
+			m.applyAssignment(ctx, assignment)
+		}
+	}
+}
+
+// closed reports whether the ShardManager was closed or the given context is done, so no shard must be opened anymore. m.shardsMu must be held.
+func (m *shardManagerImpl) closed(ctx context.Context) bool {
+	return ctx.Err() != nil || (m.config.Coordinator != nil && m.clusterCancel == nil)
+}
+
+// applyAssignment closes all shards which can't be handed over anymore, resumes the shards which moved to this node and opens the remaining ones.
+func (m *shardManagerImpl) applyAssignment(ctx context.Context, assignment Assignment) {
+	m.config.Logger.Debugf("applying shard assignment, shard count: %d, shards: %v", assignment.ShardCount, assignment.ShardIDs)
+	shardIDs := make(map[int]struct{}, len(assignment.ShardIDs))
+	for _, shardID := range assignment.ShardIDs {
+		shardIDs[shardID] = struct{}{}
+	}
+
+	m.shardsMu.Lock()
+	if m.closed(ctx) {
+		m.shardsMu.Unlock()
+		return
+	}
+	// shards which moved to another node keep running until that node hands them over.
+	// sessions of another shard count can't be resumed, so those shards are closed right away.
+	removed := map[int]gateway.Gateway{}
+	if assignment.ShardCount != m.config.ShardCount {
+		removed, m.shards = m.shards, map[int]gateway.Gateway{}
+	}
+	var added []int
+	for shardID := range shardIDs {
+		if _, ok := m.shards[shardID]; !ok {
+			added = append(added, shardID)
+		}
+	}
+	m.config.ShardCount = assignment.ShardCount
+	m.config.ShardIDs = shardIDs
+	m.shardsMu.Unlock()
+
+	m.statesMu.Lock()
+	for shardID := range removed {
+		if state, ok := m.states[shardID]; ok {
+			state.stop()
+			delete(m.states, shardID)
+		}
+	}
+	m.statesMu.Unlock()
+	closeShards(ctx, removed, "shard count changed")
+
+	var wg sync.WaitGroup
+	for i := range added {
+		shardID := added[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session, err := m.config.Coordinator.HandoverShard(ctx, shardID)
+			if err != nil {
+				m.config.Logger.Debugf("failed to hand over shard %d, identifying instead: %s", shardID, err)
+				return
+			}
+			if err = m.ResumeShard(ctx, shardID, *session); err != nil {
+				m.config.Logger.Errorf("failed to resume shard %d: %s", shardID, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// identify all shards which couldn't be handed over
+	m.openShards(ctx)
+}
@@ -1,6 +1,8 @@
 package sharding
 
 import (
+	"time"
+
 	"github.com/disgoorg/log"
 
 	"github.com/disgoorg/disgo/gateway"
@@ -12,6 +14,8 @@ func DefaultConfig() *Config {
 		Logger:            log.Default(),
 		GatewayCreateFunc: gateway.New,
 		ShardSplitCount:   2,
+		ReshardBufferSize: 10000,
+		ReshardTimeout:    10 * time.Minute,
 	}
 }
 
@@ -24,6 +28,14 @@ type Config struct {
 	AutoScaling               bool
 	GatewayCreateFunc         gateway.CreateFunc
 	GatewayConfigOpts         []gateway.ConfigOpt
+	SessionStore              gateway.SessionStore
+	Coordinator               Coordinator
+	NodeID                    string
+	RecommendedShardCountFunc RecommendedShardCountFunc
+	ReshardInterval           time.Duration
+	ReshardCallback           ReshardCallback
+	ReshardBufferSize         int
+	ReshardTimeout            time.Duration
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
 }
@@ -36,9 +48,18 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.RateLimiter == nil && c.Coordinator != nil {
+		c.RateLimiter = c.Coordinator.RateLimiter()
+	}
 	if c.RateLimiter == nil {
 		c.RateLimiter = NewRateLimiter(c.RateRateLimiterConfigOpts...)
 	}
+	if c.ShardIDs == nil {
+		c.ShardIDs = map[int]struct{}{}
+	}
+	if c.ReshardTimeout <= 0 {
+		c.ReshardTimeout = DefaultConfig().ReshardTimeout
+	}
 }
 
 // WithLogger sets the logger of the ShardManager.
@@ -67,7 +88,8 @@ func WithShardCount(shardCount int) ConfigOpt {
 	}
 }
 
-// WithShardSplitCount sets the count a shard should be split into if it is too large.
+// WithShardSplitCount sets the factor the shard count is multiplied with if discord requires re-sharding.
+// If the RecommendedShardCountFunc returns a higher shard count, that one is used instead.
 // This is only used if AutoScaling is enabled.
 func WithShardSplitCount(shardSplitCount int) ConfigOpt {
 	return func(config *Config) {
@@ -75,7 +97,7 @@ func WithShardSplitCount(shardSplitCount int) ConfigOpt {
 	}
 }
 
-// WithAutoScaling sets whether the ShardManager should automatically re-shard shards if they are too large.
+// WithAutoScaling sets whether the ShardManager should automatically re-shard via ShardManager.Reshard if discord requires re-sharding.
 func WithAutoScaling(autoScaling bool) ConfigOpt {
 	return func(config *Config) {
 		config.AutoScaling = autoScaling
@@ -96,6 +118,62 @@ func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the gateway.SessionStore which persists the sessions of all shards.
+// Shards with a stored session resume it instead of identifying again.
+func WithSessionStore(sessionStore gateway.SessionStore) ConfigOpt {
+	return func(config *Config) {
+		config.SessionStore = sessionStore
+	}
+}
+
+// WithCoordinator runs the ShardManager as the node with the given id of a cluster.
+// The shard count and shard ids are assigned by the Coordinator instead of WithShardCount & WithShardIDs and the RateLimiter of the Coordinator is used.
+func WithCoordinator(coordinator Coordinator, nodeID string) ConfigOpt {
+	return func(config *Config) {
+		config.Coordinator = coordinator
+		config.NodeID = nodeID
+	}
+}
+
+// WithRecommendedShardCountFunc sets the RecommendedShardCountFunc used by ShardManager.ReshardRecommended.
+func WithRecommendedShardCountFunc(recommendedShardCountFunc RecommendedShardCountFunc) ConfigOpt {
+	return func(config *Config) {
+		config.RecommendedShardCountFunc = recommendedShardCountFunc
+	}
+}
+
+// WithReshardInterval sets how often the ShardManager checks the recommended shard count and re-shards if needed.
+// An interval of 0 disables scheduled re-sharding.
+func WithReshardInterval(interval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ReshardInterval = interval
+	}
+}
+
+// WithReshardBufferSize sets how many events of each new shard are held back while re-sharding.
+// Once the buffer is full, only gateway.EventTypeReady & gateway.EventTypeGuildCreate events are buffered and all other events are dropped.
+// Those events are dispatched by the old shards in the meantime anyway.
+func WithReshardBufferSize(size int) ConfigOpt {
+	return func(config *Config) {
+		config.ReshardBufferSize = size
+	}
+}
+
+// WithReshardTimeout sets how long a re-sharding may take until all new shards are ready.
+// If the timeout is exceeded, the re-sharding fails and the old shards keep running. Defaults to 10 minutes.
+func WithReshardTimeout(timeout time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ReshardTimeout = timeout
+	}
+}
+
+// WithReshardCallback sets the ReshardCallback which is called with the progress of a re-sharding.
+func WithReshardCallback(reshardCallback ReshardCallback) ConfigOpt {
+	return func(config *Config) {
+		config.ReshardCallback = reshardCallback
+	}
+}
+
 // WithRateLimiter lets you inject your own srate.RateLimiter into the ShardManager.
 func WithRateLimiter(rateLimiter RateLimiter) ConfigOpt {
 	return func(config *Config) {
@@ -2,11 +2,13 @@ package sharding
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/gorilla/websocket"
 
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
 )
 
@@ -19,6 +21,7 @@ func New(token string, eventHandlerFunc gateway.EventHandlerFunc, opts ...Config
 
 	return &shardManagerImpl{
 		shards:           map[int]gateway.Gateway{},
+		states:           map[int]*shardState{},
 		token:            token,
 		eventHandlerFunc: eventHandlerFunc,
 		config:           *config,
@@ -29,73 +32,120 @@ type shardManagerImpl struct {
 	shards   map[int]gateway.Gateway
 	shardsMu sync.Mutex
 
+	// shard id -> state used for handovers
+	states   map[int]*shardState
+	statesMu sync.Mutex
+
+	reshardMu sync.Mutex
+	// cancels the scheduled re-sharding started by Open
+	scheduleCancel context.CancelFunc
+	// cancels watching the assignments of the Coordinator
+	clusterCancel context.CancelFunc
+
 	token            string
 	eventHandlerFunc gateway.EventHandlerFunc
 	config           Config
 }
 
-func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
-	if closeError, ok := err.(*websocket.CloseError); !m.config.AutoScaling || !ok || gateway.CloseEventCodeByCode(closeError.Code) != gateway.CloseEventCodeShardingRequired {
-		return
+// gatewayConfigOpts returns the gateway.ConfigOpt(s) for the shard with the given id.
+func (m *shardManagerImpl) gatewayConfigOpts(shardID int, shardCount int) []gateway.ConfigOpt {
+	opts := append([]gateway.ConfigOpt{}, m.config.GatewayConfigOpts...)
+	if m.config.SessionStore != nil {
+		opts = append(opts, gateway.WithSessionStore(m.config.SessionStore))
 	}
-	m.config.Logger.Debugf("shard %d requires re-sharding", shard.ShardID())
-	// make sure shard is closed
-	shard.Close(context.TODO())
+	return append(opts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))
+}
 
-	m.shardsMu.Lock()
-	defer m.shardsMu.Unlock()
+// newShard creates a new gateway.Gateway which dispatches its events through a shardState, so it can be handed over.
+func (m *shardManagerImpl) newShard(shardID int, shardCount int, opts ...gateway.ConfigOpt) gateway.Gateway {
+	shard, state := m.createShard(shardID, shardCount, opts...)
 
-	delete(m.shards, shard.ShardID())
-	delete(m.config.ShardIDs, shard.ShardID())
+	m.statesMu.Lock()
+	defer m.statesMu.Unlock()
+	m.states[shardID] = state
+	return shard
+}
 
-	newShardCount := shard.ShardCount() * m.config.ShardSplitCount
+// createShard creates a new gateway.Gateway and its shardState without registering it.
+func (m *shardManagerImpl) createShard(shardID int, shardCount int, opts ...gateway.ConfigOpt) (gateway.Gateway, *shardState) {
+	state := &shardState{}
+	shard := m.config.GatewayCreateFunc(m.token, state.eventHandlerFunc(m.eventHandlerFunc), m.closeHandler, append(m.gatewayConfigOpts(shardID, shardCount), opts...)...)
+	state.load(shard)
+	return shard, state
+}
 
-	if newShardCount > m.config.ShardCount {
-		m.config.ShardCount = newShardCount
+func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
+	if closeError, ok := err.(*websocket.CloseError); !m.config.AutoScaling || !ok || gateway.CloseEventCodeByCode(closeError.Code) != gateway.CloseEventCodeShardingRequired {
+		return
 	}
+	m.config.Logger.Debugf("shard %d requires re-sharding", shard.ShardID())
 
-	newShardID := shard.ShardID()
-	var newShardIDs []int
-	for len(newShardIDs) < m.config.ShardSplitCount {
-		newShardIDs = append(newShardIDs, newShardID)
-		newShardID += m.config.ShardSplitCount
+	// the session of the old shard can't be resumed with the new shard count
+	if m.config.SessionStore != nil {
+		if err := m.config.SessionStore.DeleteSession(shard.ShardID()); err != nil {
+			m.config.Logger.Errorf("failed to delete session of shard %d: %s", shard.ShardID(), err)
+		}
 	}
 
-	var wg sync.WaitGroup
-	for i := range newShardIDs {
-		shardID := newShardIDs[i]
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if err := m.config.RateLimiter.WaitBucket(context.TODO(), shardID); err != nil {
-				m.config.Logger.Errorf("failed to wait shard bucket %d: %s", shardID, err)
-				return
-			}
-			defer m.config.RateLimiter.UnlockBucket(shardID)
+	// re-shard in the background as the old shards, including this one, are closed by the re-sharding
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), m.config.ReshardTimeout)
+		defer cancel()
 
-			newShard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(newShardCount))...)
-			m.shards[shardID] = newShard
-			if err := newShard.Open(context.TODO()); err != nil {
-				m.config.Logger.Errorf("failed to re shard %d, error: %s", shardID, err)
+		shardCount := shard.ShardCount() * m.config.ShardSplitCount
+		if m.config.RecommendedShardCountFunc != nil {
+			recommendedShardCount, err := m.config.RecommendedShardCountFunc(ctx)
+			if err != nil {
+				m.config.Logger.Errorf("failed to get recommended shard count: %s", err)
+			} else if recommendedShardCount > shardCount {
+				shardCount = recommendedShardCount
 			}
-		}()
-	}
-	wg.Wait()
-	m.config.Logger.Debugf("re-sharded shard %d into newShards: %d, newShardCount: %d", shard.ShardID(), newShardIDs, newShardCount)
+		}
+		// other shards might require re-sharding at the same time, so only re-shard once
+		if err := m.reshard(ctx, shardCount, true); err != nil {
+			m.config.Logger.Errorf("failed to re-shard shard %d: %s", shard.ShardID(), err)
+		}
+	}()
 }
 
 func (m *shardManagerImpl) Open(ctx context.Context) {
-	m.config.Logger.Debugf("opening %+v shards...", m.config.ShardIDs)
-	var wg sync.WaitGroup
+	m.shardsMu.Lock()
+	if m.config.ReshardInterval > 0 && m.config.RecommendedShardCountFunc != nil && m.config.Coordinator == nil && m.scheduleCancel == nil {
+		var scheduleCtx context.Context
+		scheduleCtx, m.scheduleCancel = context.WithCancel(context.Background())
+		go m.scheduleReshard(scheduleCtx)
+	}
+	m.shardsMu.Unlock()
+
+	if m.config.Coordinator != nil {
+		m.openCluster(ctx)
+		return
+	}
+	m.openShards(ctx)
+}
 
+// openShards opens all configured shards which are not open yet.
+func (m *shardManagerImpl) openShards(ctx context.Context) {
 	m.shardsMu.Lock()
-	defer m.shardsMu.Unlock()
-	for shardInt := range m.config.ShardIDs {
-		shardID := shardInt
+	if m.closed(ctx) {
+		m.shardsMu.Unlock()
+		return
+	}
+	m.config.Logger.Debugf("opening %+v shards...", m.config.ShardIDs)
+	shards := map[int]gateway.Gateway{}
+	for shardID := range m.config.ShardIDs {
 		if _, ok := m.shards[shardID]; ok {
 			continue
 		}
+		shard := m.newShard(shardID, m.config.ShardCount)
+		m.shards[shardID] = shard
+		shards[shardID] = shard
+	}
+	m.shardsMu.Unlock()
 
+	var wg sync.WaitGroup
+	for shardInt := range shards {
+		shardID, shard := shardInt, shards[shardInt]
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -105,8 +155,6 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 			}
 			defer m.config.RateLimiter.UnlockBucket(shardID)
 
-			shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(m.config.ShardCount))...)
-			m.shards[shardID] = shard
 			if err := shard.Open(ctx); err != nil {
 				m.config.Logger.Errorf("failed to open shard %d: %s", shardID, err)
 			}
@@ -116,21 +164,44 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 }
 
 func (m *shardManagerImpl) Close(ctx context.Context) {
+	m.closeWithCode(ctx, websocket.CloseNormalClosure, "Shutting down")
+}
+
+func (m *shardManagerImpl) CloseResumable(ctx context.Context) {
+	m.closeWithCode(ctx, websocket.CloseServiceRestart, "Restarting")
+}
+
+func (m *shardManagerImpl) closeWithCode(ctx context.Context, code int, message string) {
 	m.config.Logger.Debugf("closing %v shards...", m.config.ShardIDs)
 	var wg sync.WaitGroup
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	if m.scheduleCancel != nil {
+		m.scheduleCancel()
+		m.scheduleCancel = nil
+	}
+	if m.clusterCancel != nil {
+		m.clusterCancel()
+		m.clusterCancel = nil
+	}
 	for shardID := range m.shards {
 		shard := m.shards[shardID]
 		delete(m.shards, shardID)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			shard.Close(ctx)
+			shard.CloseWithCode(ctx, code, message)
 		}()
 	}
 	wg.Wait()
+
+	// leave after our shards are closed, so the other nodes can take them over
+	if m.config.Coordinator != nil {
+		if err := m.config.Coordinator.Leave(ctx, m.config.NodeID); err != nil {
+			m.config.Logger.Errorf("failed to leave cluster: %s", err)
+		}
+	}
 }
 
 func (m *shardManagerImpl) OpenShard(ctx context.Context, shardID int) error {
@@ -144,7 +215,7 @@ func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCoun
 		return err
 	}
 	defer m.config.RateLimiter.UnlockBucket(shardID)
-	shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))...)
+	shard := m.newShard(shardID, shardCount)
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
@@ -167,7 +238,7 @@ func (m *shardManagerImpl) CloseShard(ctx context.Context, shardID int) {
 func (m *shardManagerImpl) ShardByGuildID(guildId snowflake.ID) gateway.Gateway {
 	shardCount := m.config.ShardCount
 	var shard gateway.Gateway
-	for shard == nil || shardCount != 0 {
+	for shard == nil && shardCount != 0 {
 		shard = m.Shard(ShardIDByGuild(guildId, shardCount))
 		shardCount /= m.config.ShardSplitCount
 	}
@@ -187,5 +258,75 @@ func (m *shardManagerImpl) Shards() map[int]gateway.Gateway {
 	for shardID, shard := range m.shards {
 		shards[shardID] = shard
 	}
-	return m.shards
+	return shards
+}
+
+func (m *shardManagerImpl) HandoverShard(ctx context.Context, shardID int) (*gateway.Session, error) {
+	m.config.Logger.Debugf("handing over shard %d...", shardID)
+	m.shardsMu.Lock()
+	shard, ok := m.shards[shardID]
+	m.shardsMu.Unlock()
+
+	m.statesMu.Lock()
+	state, stateOk := m.states[shardID]
+	m.statesMu.Unlock()
+	if !ok || !stateOk {
+		return nil, discord.ErrShardNotFound
+	}
+
+	session, err := state.handover()
+	if err != nil {
+		return nil, err
+	}
+
+	// don't close gracefully as this would invalidate the session
+	shard.CloseWithCode(ctx, websocket.CloseServiceRestart, "handing over shard")
+
+	m.shardsMu.Lock()
+	delete(m.shards, shardID)
+	delete(m.config.ShardIDs, shardID)
+	m.shardsMu.Unlock()
+
+	m.statesMu.Lock()
+	delete(m.states, shardID)
+	m.statesMu.Unlock()
+
+	m.config.Logger.Debugf("handed over shard %d at sequence %d", shardID, session.Sequence)
+	return &session, nil
+}
+
+func (m *shardManagerImpl) ResumeShard(ctx context.Context, shardID int, session gateway.Session) error {
+	m.config.Logger.Debugf("resuming shard %d at sequence %d...", shardID, session.Sequence)
+	if session.ShardCount != m.config.ShardCount {
+		return fmt.Errorf("session of shard %d uses %d shards but the shard manager uses %d shards", shardID, session.ShardCount, m.config.ShardCount)
+	}
+
+	opts := []gateway.ConfigOpt{gateway.WithSessionID(session.ID), gateway.WithSequence(session.Sequence)}
+	if session.ResumeURL != "" {
+		opts = append(opts, gateway.WithResumeURL(session.ResumeURL))
+	}
+	m.shardsMu.Lock()
+	if m.closed(ctx) {
+		m.shardsMu.Unlock()
+		return fmt.Errorf("failed to resume shard %d as the shard manager was closed", shardID)
+	}
+	// resuming doesn't count towards the identify rate limit
+	shard := m.newShard(shardID, m.config.ShardCount, opts...)
+	m.config.ShardIDs[shardID] = struct{}{}
+	m.shards[shardID] = shard
+	m.shardsMu.Unlock()
+	return shard.Open(ctx)
+}
+
+func (m *shardManagerImpl) TakeOverShards(ctx context.Context, source HandoverSource, shardIDs ...int) error {
+	for _, shardID := range shardIDs {
+		session, err := source.HandoverShard(ctx, shardID)
+		if err != nil {
+			return err
+		}
+		if err = m.ResumeShard(ctx, shardID, *session); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,288 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ReshardStage is the stage of a re-sharding started by ShardManager.Reshard.
+type ReshardStage int
+
+const (
+	// ReshardStageStarted is reported once the new shards start booting.
+	ReshardStageStarted ReshardStage = iota
+	// ReshardStageShardReady is reported for every new shard which received its ready event.
+	ReshardStageShardReady
+	// ReshardStageSwapped is reported once the new shards replaced the old shards.
+	ReshardStageSwapped
+	// ReshardStageFailed is reported if a new shard failed to boot. The old shards keep running.
+	ReshardStageFailed
+)
+
+// ReshardProgress is passed to the ReshardCallback during a re-sharding.
+type ReshardProgress struct {
+	Stage         ReshardStage
+	OldShardCount int
+	NewShardCount int
+	// ShardID is the new shard which is ready. It is only set for ReshardStageShardReady.
+	ShardID int
+	// ReadyShards is the number of new shards which are ready.
+	ReadyShards int
+	// Err is the error which caused the re-sharding to fail. It is only set for ReshardStageFailed.
+	Err error
+}
+
+// ReshardCallback is called with the progress of a re-sharding.
+type ReshardCallback func(progress ReshardProgress)
+
+// RecommendedShardCountFunc returns the shard count recommended by discord. bot.Client uses rest.Gateway.GetGatewayBot for it.
+type RecommendedShardCountFunc func(ctx context.Context) (int, error)
+
+func (m *shardManagerImpl) reshardProgress(progress ReshardProgress) {
+	if m.config.ReshardCallback != nil {
+		m.config.ReshardCallback(progress)
+	}
+}
+
+func (m *shardManagerImpl) Reshard(ctx context.Context, shardCount int) error {
+	return m.reshard(ctx, shardCount, false)
+}
+
+// reshard re-shards to the given shard count. If grow is true, it only re-shards if the shard count is higher than the current one.
+func (m *shardManagerImpl) reshard(ctx context.Context, shardCount int, grow bool) error {
+	if m.config.Coordinator != nil {
+		return discord.ErrShardsCoordinated
+	}
+	if shardCount < 1 {
+		return fmt.Errorf("invalid shard count %d, at least 1 shard is required", shardCount)
+	}
+
+	m.reshardMu.Lock()
+	defer m.reshardMu.Unlock()
+
+	m.shardsMu.Lock()
+	oldShardCount := m.config.ShardCount
+	shardIDs, err := reshardShardIDs(m.config.ShardIDs, oldShardCount, shardCount)
+	m.shardsMu.Unlock()
+	if grow && shardCount <= oldShardCount {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.config.ReshardTimeout)
+	defer cancel()
+
+	m.config.Logger.Debugf("re-sharding from %d to %d shards...", oldShardCount, shardCount)
+	m.reshardProgress(ReshardProgress{
+		Stage:         ReshardStageStarted,
+		OldShardCount: oldShardCount,
+		NewShardCount: shardCount,
+	})
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		shards      = make(map[int]gateway.Gateway, len(shardIDs))
+		states      = make(map[int]*shardState, len(shardIDs))
+		readyShards int
+		bootErr     error
+	)
+	for i := range shardIDs {
+		shardID := i
+		wg.Add(1)
+		// the RateLimiter makes sure only max_concurrency shards identify at the same time
+		go func() {
+			defer wg.Done()
+			ready := make(chan struct{})
+			shard, state := m.createShard(shardID, shardCount, withReadyNotify(ready))
+			// hold back all events until the new shards replace the old ones
+			state.startBuffering(m.config.ReshardBufferSize)
+
+			mu.Lock()
+			shards[shardID], states[shardID] = shard, state
+			mu.Unlock()
+
+			err := m.bootShard(ctx, shard, ready)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if bootErr == nil {
+					bootErr = err
+				}
+				return
+			}
+			readyShards++
+			m.reshardProgress(ReshardProgress{
+				Stage:         ReshardStageShardReady,
+				OldShardCount: oldShardCount,
+				NewShardCount: shardCount,
+				ShardID:       shardID,
+				ReadyShards:   readyShards,
+			})
+		}()
+	}
+	wg.Wait()
+
+	if bootErr != nil {
+		m.config.Logger.Errorf("failed to re-shard to %d shards: %s", shardCount, bootErr)
+		for _, state := range states {
+			state.stop()
+		}
+		closeShards(ctx, shards, "re-sharding failed")
+		m.reshardProgress(ReshardProgress{
+			Stage:         ReshardStageFailed,
+			OldShardCount: oldShardCount,
+			NewShardCount: shardCount,
+			ReadyShards:   readyShards,
+			Err:           bootErr,
+		})
+		return bootErr
+	}
+
+	// swap the shards while no new shard can be opened
+	m.shardsMu.Lock()
+	m.statesMu.Lock()
+	for _, state := range m.states {
+		state.stop()
+	}
+	oldShards := m.shards
+	m.shards = shards
+	m.states = states
+	m.config.ShardCount = shardCount
+	m.config.ShardIDs = shardIDs
+	m.statesMu.Unlock()
+	m.shardsMu.Unlock()
+
+	for shardID, state := range states {
+		if dropped := state.flush(); dropped > 0 {
+			m.config.Logger.Warnf("dropped %d events of shard %d while re-sharding as the buffer was full", dropped, shardID)
+		}
+	}
+	closeShards(ctx, oldShards, "re-sharded")
+
+	m.config.Logger.Debugf("re-sharded from %d to %d shards", oldShardCount, shardCount)
+	m.reshardProgress(ReshardProgress{
+		Stage:         ReshardStageSwapped,
+		OldShardCount: oldShardCount,
+		NewShardCount: shardCount,
+		ReadyShards:   readyShards,
+	})
+	return nil
+}
+
+// reshardShardIDs returns the shard ids which replace the given shard ids when re-sharding from oldShardCount to shardCount.
+// A ShardManager which manages all shards gets all new shards. Otherwise, the guilds of shard id move to the shards id, id+oldShardCount, ...
+// which requires shardCount to be a multiple of oldShardCount.
+func reshardShardIDs(shardIDs map[int]struct{}, oldShardCount int, shardCount int) (map[int]struct{}, error) {
+	allShards := true
+	for shardID := 0; shardID < oldShardCount; shardID++ {
+		if _, ok := shardIDs[shardID]; !ok {
+			allShards = false
+			break
+		}
+	}
+
+	newShardIDs := make(map[int]struct{}, shardCount)
+	if allShards {
+		for shardID := 0; shardID < shardCount; shardID++ {
+			newShardIDs[shardID] = struct{}{}
+		}
+		return newShardIDs, nil
+	}
+	if shardCount%oldShardCount != 0 {
+		return nil, fmt.Errorf("failed to re-shard shards %v from %d to %d shards as %d is not a multiple of %d", shardIDs, oldShardCount, shardCount, shardCount, oldShardCount)
+	}
+	for shardID := range shardIDs {
+		for newShardID := shardID; newShardID < shardCount; newShardID += oldShardCount {
+			newShardIDs[newShardID] = struct{}{}
+		}
+	}
+	return newShardIDs, nil
+}
+
+// bootShard opens the given shard and waits until it is ready.
+func (m *shardManagerImpl) bootShard(ctx context.Context, shard gateway.Gateway, ready <-chan struct{}) error {
+	if err := m.config.RateLimiter.WaitBucket(ctx, shard.ShardID()); err != nil {
+		return err
+	}
+	err := shard.Open(ctx)
+	m.config.RateLimiter.UnlockBucket(shard.ShardID())
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ready:
+		return nil
+	}
+}
+
+func (m *shardManagerImpl) ReshardRecommended(ctx context.Context) error {
+	if m.config.RecommendedShardCountFunc == nil {
+		return nil
+	}
+	shardCount, err := m.config.RecommendedShardCountFunc(ctx)
+	if err != nil {
+		return err
+	}
+	return m.reshard(ctx, shardCount, true)
+}
+
+// scheduleReshard checks the recommended shard count every ReshardInterval until the context is done.
+func (m *shardManagerImpl) scheduleReshard(ctx context.Context) {
+	ticker := time.NewTicker(m.config.ReshardInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.ReshardRecommended(ctx); err != nil {
+				m.config.Logger.Errorf("failed to re-shard to the recommended shard count: %s", err)
+			}
+		}
+	}
+}
+
+// withReadyNotify closes the given channel once the gateway.Gateway is ready. A gateway.StatusChangeFunc configured by the user is still called.
+func withReadyNotify(ready chan<- struct{}) gateway.ConfigOpt {
+	return func(config *gateway.Config) {
+		statusChangeFunc := config.StatusChangeFunc
+		var once sync.Once
+		config.StatusChangeFunc = func(g gateway.Gateway, change gateway.StatusChange) {
+			if statusChangeFunc != nil {
+				statusChangeFunc(g, change)
+			}
+			if change.NewStatus == gateway.StatusReady {
+				once.Do(func() {
+					close(ready)
+				})
+			}
+		}
+	}
+}
+
+// closeShards closes the given shards without deleting their sessions, as the other shards with the same ids might still use them.
+func closeShards(ctx context.Context, shards map[int]gateway.Gateway, message string) {
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		wg.Add(1)
+		go func(shard gateway.Gateway) {
+			defer wg.Done()
+			shard.CloseWithCode(ctx, websocket.CloseServiceRestart, message)
+		}(shard)
+	}
+	wg.Wait()
+}
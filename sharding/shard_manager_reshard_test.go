@@ -0,0 +1,170 @@
+package sharding
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var _ RateLimiter = testRateLimiter{}
+
+type testRateLimiter struct{}
+
+func (testRateLimiter) Close(context.Context)                 {}
+func (testRateLimiter) WaitBucket(context.Context, int) error { return nil }
+func (testRateLimiter) UnlockBucket(int)                      {}
+
+func TestReshard(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		shardIDs = map[int]int{}
+		stages   []ReshardStage
+	)
+	manager := New("token", func(_ gateway.EventType, _ int, shardID int, _ gateway.EventData) {
+		mu.Lock()
+		defer mu.Unlock()
+		shardIDs[shardID]++
+	},
+		WithGatewayCreateFunc(newTestGateway),
+		WithRateLimiter(testRateLimiter{}),
+		WithShardCount(1),
+		WithShardIDs(0),
+		WithReshardCallback(func(progress ReshardProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			stages = append(stages, progress.Stage)
+			assert.Equal(t, 1, progress.OldShardCount)
+			assert.Equal(t, 2, progress.NewShardCount)
+		}),
+		WithRecommendedShardCountFunc(func(context.Context) (int, error) {
+			return 2, nil
+		}),
+	)
+	manager.Open(context.Background())
+	oldShard := manager.Shard(0)
+
+	assert.NoError(t, manager.ReshardRecommended(context.Background()))
+	defer manager.Close(context.Background())
+
+	shards := manager.Shards()
+	assert.Len(t, shards, 2)
+	assert.NotSame(t, oldShard, shards[0])
+	// the new shard 0 might still use the session store entry of the old shard 0
+	assert.Equal(t, websocket.CloseServiceRestart, oldShard.(*testGateway).closeCode)
+	for _, shard := range shards {
+		assert.Equal(t, 2, shard.ShardCount())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []ReshardStage{ReshardStageStarted, ReshardStageShardReady, ReshardStageShardReady, ReshardStageSwapped}, stages)
+	// the buffered events of the new shards are dispatched after the swap
+	assert.NotZero(t, shardIDs[1])
+}
+
+func TestReshardShardSubset(t *testing.T) {
+	manager := New("token", func(gateway.EventType, int, int, gateway.EventData) {},
+		WithGatewayCreateFunc(newTestGateway),
+		WithRateLimiter(testRateLimiter{}),
+		WithShardCount(2),
+		WithShardIDs(1),
+	)
+	manager.Open(context.Background())
+	defer manager.Close(context.Background())
+
+	assert.NoError(t, manager.Reshard(context.Background(), 4))
+	assert.Equal(t, []int{1, 3}, shardIDsOf(manager))
+
+	// the guilds of shard 1 & 3 can't be moved to 6 shards without the other shards
+	assert.Error(t, manager.Reshard(context.Background(), 6))
+	assert.Equal(t, []int{1, 3}, shardIDsOf(manager))
+}
+
+// unreadyGateway never becomes ready.
+type unreadyGateway struct {
+	*testGateway
+}
+
+func (*unreadyGateway) Open(context.Context) error                 { return nil }
+func (*unreadyGateway) CloseWithCode(context.Context, int, string) {}
+
+func TestReshardTimeout(t *testing.T) {
+	var stages []ReshardStage
+	manager := New("token", func(gateway.EventType, int, int, gateway.EventData) {},
+		WithGatewayCreateFunc(func(token string, eventHandlerFunc gateway.EventHandlerFunc, closeHandlerFunc gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+			return &unreadyGateway{testGateway: newTestGateway(token, eventHandlerFunc, closeHandlerFunc, opts...).(*testGateway)}
+		}),
+		WithRateLimiter(testRateLimiter{}),
+		WithShardCount(1),
+		WithShardIDs(0),
+		WithReshardTimeout(50*time.Millisecond),
+		WithReshardCallback(func(progress ReshardProgress) {
+			stages = append(stages, progress.Stage)
+		}),
+	)
+	manager.Open(context.Background())
+	oldShard := manager.Shard(0)
+
+	assert.ErrorIs(t, manager.Reshard(context.Background(), 2), context.DeadlineExceeded)
+	assert.Equal(t, []ReshardStage{ReshardStageStarted, ReshardStageFailed}, stages)
+	assert.Same(t, oldShard, manager.Shard(0))
+
+	// the failed re-sharding doesn't block the next one
+	assert.ErrorIs(t, manager.Reshard(context.Background(), 2), context.DeadlineExceeded)
+}
+
+func TestReshardInvalid(t *testing.T) {
+	manager := New("token", func(gateway.EventType, int, int, gateway.EventData) {},
+		WithGatewayCreateFunc(newTestGateway),
+		WithRateLimiter(testRateLimiter{}),
+	)
+	assert.Error(t, manager.Reshard(context.Background(), 0))
+
+	coordinated := New("token", func(gateway.EventType, int, int, gateway.EventData) {},
+		WithGatewayCreateFunc(newTestGateway),
+		WithCoordinator(NewLocalCoordinator(1, testRateLimiter{}), "a"),
+	)
+	assert.ErrorIs(t, coordinated.Reshard(context.Background(), 2), discord.ErrShardsCoordinated)
+}
+
+func TestShardStateBufferSize(t *testing.T) {
+	state := &shardState{}
+	var dispatched []gateway.EventType
+	handlerFunc := state.eventHandlerFunc(func(eventType gateway.EventType, _ int, _ int, _ gateway.EventData) {
+		dispatched = append(dispatched, eventType)
+	})
+
+	state.startBuffering(1)
+	handlerFunc(gateway.EventTypeReady, 1, 0, gateway.EventReady{})
+	handlerFunc(gateway.EventTypeMessageCreate, 2, 0, gateway.EventMessageCreate{})
+	handlerFunc(gateway.EventTypeGuildCreate, 3, 0, gateway.EventGuildCreate{})
+	assert.Empty(t, dispatched)
+
+	assert.Equal(t, 1, state.flush())
+	assert.Equal(t, []gateway.EventType{gateway.EventTypeReady, gateway.EventTypeGuildCreate}, dispatched)
+}
+
+func TestReshardOnShardingRequired(t *testing.T) {
+	manager := New("token", func(gateway.EventType, int, int, gateway.EventData) {},
+		WithGatewayCreateFunc(newTestGateway),
+		WithRateLimiter(testRateLimiter{}),
+		WithShardCount(1),
+		WithShardIDs(0),
+		WithAutoScaling(true),
+	)
+	manager.Open(context.Background())
+	defer manager.Close(context.Background())
+
+	impl := manager.(*shardManagerImpl)
+	impl.closeHandler(manager.Shard(0), &websocket.CloseError{Code: gateway.CloseEventCodeShardingRequired.Code})
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]int{0, 1}, shardIDsOf(manager))
+	}, 5*time.Second, 10*time.Millisecond)
+}